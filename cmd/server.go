@@ -7,6 +7,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"sync"
 	"syscall"
 	"time"
@@ -87,18 +88,84 @@ func connectionLimiterMiddleware(next ssh.Handler) ssh.Handler {
 	}
 }
 
+// snapshotCommandMiddleware answers `ssh host snapshot.png > map.png` and
+// `ssh host snapshot.ansi [width]` without starting the game ui.
+func snapshotCommandMiddleware(next ssh.Handler) ssh.Handler {
+	return func(s ssh.Session) {
+		command := s.Command()
+		if len(command) == 0 {
+			next(s)
+			return
+		}
+
+		gameManager := game.GetNewGameManager()
+		go gameManager.StartGameLoop()
+
+		var err error
+		switch command[0] {
+		case "snapshot.png":
+			err = gameManager.WriteCachedMapPNG(s)
+		case "snapshot.ansi":
+			width := 0
+			if len(command) > 1 {
+				width, _ = strconv.Atoi(command[1])
+			}
+			err = gameManager.WriteCachedMapANSI(s, width)
+		default:
+			fmt.Fprintf(s.Stderr(), "Unknown command %q, try snapshot.png or snapshot.ansi [width]\r\n", command[0])
+			s.Exit(1)
+			return
+		}
+
+		if err != nil {
+			log.Error("Failed to export map", "command", command[0], "error", err)
+			s.Exit(1)
+			return
+		}
+		s.Exit(0)
+	}
+}
+
+// saveSnapshotsOnSignal is the admin action: `kill -USR1 <pid>` drops a PNG and
+// an ANSI dump of the map into OUROBOROS_SNAPSHOT_DIR.
+func saveSnapshotsOnSignal() {
+	snapshotDir := os.Getenv(game.SnapshotDirEnv)
+	if snapshotDir == "" {
+		snapshotDir = game.DefaultSnapshotDir
+	}
+
+	snapshotChannel := make(chan os.Signal, 1)
+	signal.Notify(snapshotChannel, syscall.SIGUSR1)
+	for range snapshotChannel {
+		written, err := game.GetNewGameManager().SaveMapSnapshot(snapshotDir)
+		if err != nil {
+			log.Error("Failed to save map snapshot", "error", err)
+			continue
+		}
+		log.Info("Saved map snapshot", "files", written)
+	}
+}
+
 func main() {
 	log.SetLevel(log.DebugLevel)
 
 	sshPKeyPath := os.Getenv("OUROBOROS_PRIVATE_KEY_PATH")
 
+	// one profile store for every session, the game runs without profiles if it won't open
+	profiles, profilesErr := game.NewProfileService()
+	if profilesErr != nil {
+		log.Error("Profiles are unavailable", "error", profilesErr)
+	}
+	defer profiles.Close()
+
 	sshServer, serverCreateErr := wish.NewServer(
 		wish.WithAddress(host+":"+port),
 		wish.WithHostKeyPath(sshPKeyPath),
 		wish.WithMiddleware(
-			bubbletea.Middleware(viewHandler),
+			bubbletea.Middleware(newViewHandler(profiles)),
 			logging.Middleware(),
 			activeterm.Middleware(),
+			snapshotCommandMiddleware,
 			connectionLimiterMiddleware,
 		),
 	)
@@ -109,6 +176,8 @@ func main() {
 	serverDoneChannel := make(chan os.Signal, 1)
 	// Captturing system signal to kill server
 	signal.Notify(serverDoneChannel, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+	go saveSnapshotsOnSignal()
+
 	log.Info("Starting SSH server", "host", host, "port", port)
 	go func() {
 		if err := sshServer.ListenAndServe(); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
@@ -127,11 +196,13 @@ func main() {
 	}
 }
 
-func viewHandler(sshSession ssh.Session) (tea.Model, []tea.ProgramOption) {
-	pty, _, _ := sshSession.Pty()
-	gameManager := game.GetNewGameManager()
-	go gameManager.StartGameLoop()
-	controllerModel := ui.NewControllerModel(gameManager, sshSession, pty.Window.Width, pty.Window.Height)
+func newViewHandler(profiles *game.ProfileService) bubbletea.Handler {
+	return func(sshSession ssh.Session) (tea.Model, []tea.ProgramOption) {
+		pty, _, _ := sshSession.Pty()
+		gameManager := game.GetNewGameManager()
+		go gameManager.StartGameLoop()
+		controllerModel := ui.NewControllerModel(gameManager, profiles, sshSession, pty.Window.Width, pty.Window.Height)
 
-	return controllerModel, []tea.ProgramOption{tea.WithAltScreen()}
+		return controllerModel, []tea.ProgramOption{tea.WithAltScreen()}
+	}
 }
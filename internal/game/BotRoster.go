@@ -0,0 +1,184 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// BotProfile describes one bot in the roster file. Strategy and Color are
+// optional, a zero MaxLives means the bot keeps coming back forever.
+type BotProfile struct {
+	Name           string `json:"name"`
+	Strategy       string `json:"strategy,omitempty"`
+	Color          *int   `json:"color,omitempty"`
+	RespawnDelayMs int    `json:"respawn_delay_ms,omitempty"`
+	MaxLives       int    `json:"max_lives,omitempty"`
+}
+
+type BotRoster struct {
+	DefaultRespawnDelayMs int          `json:"default_respawn_delay_ms"`
+	MaxRespawnDelayMs     int          `json:"max_respawn_delay_ms"`
+	Bots                  []BotProfile `json:"bots"`
+}
+
+type botSlotState int
+
+const (
+	botSlotAlive botSlotState = iota
+	botSlotRespawning
+	botSlotParked     // benched by the population controller
+	botSlotHumanHeld  // a human is playing this color
+	botSlotOutOfLives // used up MaxLives
+)
+
+// botSlot is a roster entry bound to a color for the lifetime of the server.
+// deaths counts every life lost, recentDeaths only the ones since the bot
+// last survived botBackoffResetDuration and drives the respawn backoff.
+type botSlot struct {
+	profile      BotProfile
+	color        int
+	deaths       int
+	recentDeaths int
+	spawnedAt    time.Time
+	state        botSlotState
+}
+
+func LoadBotRoster(path string) (*BotRoster, error) {
+	rosterBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bot roster %s: %w", path, err)
+	}
+
+	roster := &BotRoster{
+		DefaultRespawnDelayMs: defaultBotRespawnDelayMs,
+		MaxRespawnDelayMs:     maxBotRespawnDelayMs,
+	}
+	if err := json.Unmarshal(rosterBytes, roster); err != nil {
+		return nil, fmt.Errorf("failed to parse bot roster %s: %w", path, err)
+	}
+
+	for i, profile := range roster.Bots {
+		if profile.Name == "" {
+			return nil, fmt.Errorf("bot #%d in roster %s has no name", i, path)
+		}
+		if _, ok := BotStrategies[profile.Strategy]; profile.Strategy != "" && !ok {
+			return nil, fmt.Errorf("bot %s in roster %s has unknown strategy %q", profile.Name, path, profile.Strategy)
+		}
+	}
+
+	return roster, nil
+}
+
+// loadBotRoster reads the roster pointed to by BotRosterPathEnv and falls back
+// to the built-in funny names when it is unset or broken.
+func loadBotRoster() *BotRoster {
+	rosterPath := os.Getenv(BotRosterPathEnv)
+	if rosterPath == "" {
+		return defaultBotRoster()
+	}
+
+	roster, err := LoadBotRoster(rosterPath)
+	if err != nil {
+		log.Printf("Falling back to default bot roster: %v", err)
+		return defaultBotRoster()
+	}
+
+	log.Printf("Loaded %d bots from roster %s", len(roster.Bots), rosterPath)
+	return roster
+}
+
+func defaultBotRoster() *BotRoster {
+	roster := &BotRoster{
+		DefaultRespawnDelayMs: defaultBotRespawnDelayMs,
+		MaxRespawnDelayMs:     maxBotRespawnDelayMs,
+	}
+
+	for color, name := range funnyBotNames {
+		if _, ok := SystemColors[color]; ok {
+			continue
+		}
+		botColor := color
+		roster.Bots = append(roster.Bots, BotProfile{Name: name, Color: &botColor})
+	}
+
+	return roster
+}
+
+// assignColors binds up to limit roster entries to free colors. Entries with an
+// explicit color get it first, the rest take the lowest colors left over.
+func (r *BotRoster) assignColors(limit int) []*botSlot {
+	takenColors := make(map[int]bool)
+	for color := range SystemColors {
+		takenColors[color] = true
+	}
+
+	slots := []*botSlot{}
+	pending := []BotProfile{}
+	for _, profile := range r.Bots {
+		if len(slots)+len(pending) >= limit {
+			break
+		}
+		if profile.Color != nil && *profile.Color >= 0 && *profile.Color < 256 && !takenColors[*profile.Color] {
+			takenColors[*profile.Color] = true
+			slots = append(slots, &botSlot{profile: profile, color: *profile.Color})
+			continue
+		}
+		pending = append(pending, profile)
+	}
+
+	nextColor := 0
+	for _, profile := range pending {
+		for nextColor < 256 && takenColors[nextColor] {
+			nextColor++
+		}
+		if nextColor >= 256 {
+			log.Printf("No colors left for bot %s", profile.Name)
+			break
+		}
+		takenColors[nextColor] = true
+		slots = append(slots, &botSlot{profile: profile, color: nextColor})
+	}
+
+	return slots
+}
+
+// respawnDelay doubles the base delay with every recent death, capped by the roster.
+func (r *BotRoster) respawnDelay(slot *botSlot) time.Duration {
+	delayMs := r.DefaultRespawnDelayMs
+	if slot.profile.RespawnDelayMs > 0 {
+		delayMs = slot.profile.RespawnDelayMs
+	}
+
+	for i := 1; i < slot.recentDeaths && delayMs < r.MaxRespawnDelayMs; i++ {
+		delayMs *= 2
+	}
+	if r.MaxRespawnDelayMs > 0 {
+		delayMs = min(delayMs, r.MaxRespawnDelayMs)
+	}
+
+	return time.Duration(delayMs) * time.Millisecond
+}
+
+// recordDeath counts a lost life, a bot that stayed alive long enough starts
+// its backoff over.
+func (slot *botSlot) recordDeath() {
+	if time.Since(slot.spawnedAt) >= botBackoffResetDuration {
+		slot.recentDeaths = 0
+	}
+	slot.deaths++
+	slot.recentDeaths++
+}
+
+func (slot *botSlot) strategy() Strategy {
+	if strategy, ok := BotStrategies[slot.profile.Strategy]; ok {
+		return strategy
+	}
+	return pickBotStrategy()
+}
+
+func (slot *botSlot) hasLivesLeft() bool {
+	return slot.profile.MaxLives <= 0 || slot.deaths < slot.profile.MaxLives
+}
@@ -1,5 +1,86 @@
 package game
 
+import "math/rand"
+
 type Strategy interface {
 	getNextBestDirection(player *Player, gm *GameManager) Direction
 }
+
+var (
+	defaultStrategy = &DefaultStrategy{}
+	hunterStrategy  = NewHunterStrategy(true)
+	turtleStrategy  = &TurtleStrategy{}
+)
+
+// BotStrategies maps strategy names to the shared strategy instances.
+var BotStrategies = map[string]Strategy{
+	"default": defaultStrategy,
+	"hunter":  hunterStrategy,
+	"turtle":  turtleStrategy,
+}
+
+// pickBotStrategy returns a random personality, most bots stay default wanderers.
+func pickBotStrategy() Strategy {
+	roll := rand.Intn(100)
+	switch {
+	case roll < 20:
+		return hunterStrategy
+	case roll < 35:
+		return turtleStrategy
+	default:
+		return defaultStrategy
+	}
+}
+
+// getValidMoves returns every direction the player can take next tick without
+// reversing, hitting the wall or running into an opponent's head.
+func getValidMoves(player *Player, gm *GameManager) map[Direction]*Tile {
+	currentTile := player.Location
+	validMoves := make(map[Direction]*Tile)
+
+	for _, dirCoords := range Directions {
+		dx, dy := dirCoords[1], dirCoords[0]
+		nextX := currentTile.X + dx
+		nextY := currentTile.Y + dy
+
+		dir := Direction{Dx: dx, Dy: dy, PlayerColor: *player.Color}
+
+		if dx == -player.CurrentDirection.Dx && dy == -player.CurrentDirection.Dy {
+			continue
+		}
+
+		nextTile := tileAt(gm.GameMap, nextY, nextX)
+		if nextTile == nil {
+			continue
+		}
+
+		if gm.SpatialIndex.HeadAt(nextTile, *player.Color) != nil {
+			continue
+		}
+
+		validMoves[dir] = nextTile
+	}
+
+	return validMoves
+}
+
+// getDirectionTowards picks the valid move that gets closest to target,
+// preferring to keep the current heading on ties.
+func getDirectionTowards(player *Player, target *Tile, validMoves map[Direction]*Tile) Direction {
+	bestDir := player.CurrentDirection
+	minDist := -1
+
+	for dir, tile := range validMoves {
+		dist := GetManhattanDistance(tile, target) * 2
+		if dir.Dx == player.CurrentDirection.Dx && dir.Dy == player.CurrentDirection.Dy {
+			dist -= 1
+		}
+
+		if minDist == -1 || dist < minDist {
+			minDist = dist
+			bestDir = dir
+		}
+	}
+
+	return bestDir
+}
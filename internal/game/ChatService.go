@@ -0,0 +1,146 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/ssh"
+)
+
+// ChatMessage is delivered to every player that hasn't muted the sender.
+type ChatMessage struct {
+	SenderName  string
+	SenderColor int
+	Text        string
+}
+
+// ChatService relays chat between players. Rate limits and mute lists are kept
+// per ssh session, so dying and rejoining doesn't reset either of them.
+type ChatService struct {
+	GameManager *GameManager
+
+	chatLock  sync.Mutex
+	sentTimes map[ssh.Session][]time.Time
+	muted     map[ssh.Session]map[string]bool
+}
+
+func NewChatService(gameManager *GameManager) *ChatService {
+	return &ChatService{
+		GameManager: gameManager,
+		sentTimes:   make(map[ssh.Session][]time.Time),
+		muted:       make(map[ssh.Session]map[string]bool),
+	}
+}
+
+func ValidateChatMessage(text string) error {
+	trimmedText := strings.TrimSpace(text)
+
+	if len(trimmedText) == 0 {
+		return errors.New("message is empty")
+	}
+
+	if utf8.RuneCountInString(text) > ChatMaxLength {
+		return fmt.Errorf("message must be %d characters or less", ChatMaxLength)
+	}
+
+	for _, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsPunct(r) && r != ' ' {
+			return errors.New("message must not contain special characters")
+		}
+	}
+	return nil
+}
+
+// Send validates and rate limits the message, then hands it to everybody
+// except the players who muted the sender.
+func (cs *ChatService) Send(sender *Player, text string) error {
+	if err := ValidateChatMessage(text); err != nil {
+		return err
+	}
+	if err := cs.takeRateLimitSlot(sender.SshSession); err != nil {
+		return err
+	}
+
+	message := ChatMessage{
+		SenderName:  sender.Name,
+		SenderColor: *sender.Color,
+		Text:        strings.TrimSpace(text),
+	}
+
+	cs.GameManager.Players.Range(func(_, value interface{}) bool {
+		player, ok := value.(*Player)
+		if !ok || player == nil || player.BotStrategy != nil || player.isDead {
+			return true
+		}
+		if cs.IsMuted(player.SshSession, sender.Name) {
+			return true
+		}
+
+		select {
+		case player.UpdateChannel <- message:
+		default:
+			log.Printf("Player %s update channel full, dropping chat message", player.Name)
+		}
+		return true
+	})
+
+	return nil
+}
+
+// takeRateLimitSlot allows chatBurst messages per chatRateWindow.
+func (cs *ChatService) takeRateLimitSlot(session ssh.Session) error {
+	cs.chatLock.Lock()
+	defer cs.chatLock.Unlock()
+
+	now := time.Now()
+	recent := []time.Time{}
+	for _, sentAt := range cs.sentTimes[session] {
+		if now.Sub(sentAt) < chatRateWindow {
+			recent = append(recent, sentAt)
+		}
+	}
+
+	if len(recent) >= chatBurst {
+		cs.sentTimes[session] = recent
+		return fmt.Errorf("slow down, wait %ds", int((chatRateWindow-now.Sub(recent[0])).Seconds())+1)
+	}
+
+	cs.sentTimes[session] = append(recent, now)
+	return nil
+}
+
+func (cs *ChatService) Mute(session ssh.Session, name string) {
+	cs.chatLock.Lock()
+	defer cs.chatLock.Unlock()
+
+	if cs.muted[session] == nil {
+		cs.muted[session] = make(map[string]bool)
+	}
+	cs.muted[session][name] = true
+}
+
+func (cs *ChatService) Unmute(session ssh.Session, name string) {
+	cs.chatLock.Lock()
+	defer cs.chatLock.Unlock()
+	delete(cs.muted[session], name)
+}
+
+func (cs *ChatService) IsMuted(session ssh.Session, name string) bool {
+	cs.chatLock.Lock()
+	defer cs.chatLock.Unlock()
+	return cs.muted[session][name]
+}
+
+// Forget drops everything kept for a session once it disconnects.
+func (cs *ChatService) Forget(session ssh.Session) {
+	cs.chatLock.Lock()
+	defer cs.chatLock.Unlock()
+	delete(cs.sentTimes, session)
+	delete(cs.muted, session)
+}
@@ -14,10 +14,39 @@ var Directions = [][]int{
 func GetManhattanDistance(t1, t2 *Tile) int {
 	dx := math.Abs(float64(t1.X - t2.X))
 	dy := math.Abs(float64(t1.Y - t2.Y))
+	if WrapAround {
+		// going around the back of the torus may be shorter
+		dx = math.Min(dx, float64(MapColCount)-dx)
+		dy = math.Min(dy, float64(MapRowCount)-dy)
+	}
 	return int(dx + dy)
 }
 
+// WrapCoords folds coordinates back onto the map in wrap-around mode and
+// leaves them alone otherwise.
+func WrapCoords(row int, col int) (int, int) {
+	if !WrapAround {
+		return row, col
+	}
+	return ((row % MapRowCount) + MapRowCount) % MapRowCount, ((col % MapColCount) + MapColCount) % MapColCount
+}
+
+// tileAt returns the tile at row, col (wrapped when the arena wraps) or nil
+// when there is a wall there.
+func tileAt(gameMap [][]*Tile, row int, col int) *Tile {
+	if IsWall(row, col) {
+		return nil
+	}
+	row, col = WrapCoords(row, col)
+	return gameMap[row][col]
+}
+
 func IsWall(row int, col int) bool {
+	if WrapAround {
+		row, col = WrapCoords(row, col)
+		return GameMap != nil && GameMap[row][col].Terrain == TerrainWall
+	}
+
 	if row <= 0 || col <= 0 {
 		return true
 	}
@@ -26,7 +55,7 @@ func IsWall(row int, col int) bool {
 		return true
 	}
 
-	return false
+	return GameMap != nil && GameMap[row][col].Terrain == TerrainWall
 }
 
 var funnyBotNames = []string{
@@ -1,17 +1,94 @@
 package game
 
-import "time"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
 const (
-	GameTickDuration          = 70 * time.Millisecond
-	VoidColor                 = 233
-	WallColor                 = 172
-	sunsetWorkersCount        = 100
-	rebirthWorkerCount        = 3
-	spaceFillerChannelWorkers = 256
-	botCount                  = 150
-	MapColCount               = 1000
-	MapRowCount               = 1000
+	GameTickDuration            = 70 * time.Millisecond
+	VoidColor                   = 233
+	WallColor                   = 172
+	NoBuildColor                = 24
+	sunsetWorkersCount          = 100
+	rebirthWorkerCount          = 3
+	spaceFillerChannelWorkers   = 256
+	botCount                    = 150
+	spatialCellSize             = 25
+	defaultBotRespawnDelayMs    = 2000
+	maxBotRespawnDelayMs        = 30000
+	botBackoffResetDuration     = time.Minute
+	BotRosterPathEnv            = "OUROBOROS_BOT_ROSTER_PATH"
+	targetPopulation            = 150
+	minBotCount                 = 20
+	populationStep              = 3
+	populationCheckInterval     = time.Second
+	populationRegionSize        = 250
+	maxBotsPerHumanInRegion     = 8
+	consolidateSlack            = 64
+	MapPathEnv                  = "OUROBOROS_MAP_PATH"
+	minMapSize                  = 50
+	MapSeedEnv                  = "OUROBOROS_MAP_SEED"
+	RoundMinutesEnv             = "OUROBOROS_ROUND_MINUTES"
+	defaultRockDensity          = 0.04
+	defaultCorridorCount        = 12
+	defaultRingCount            = 5
+	maxMapGeneratorAttempts     = 10
+	mapGeneratorBorderMargin    = 15
+	mapGeneratorSpawnClearance  = 5
+	ArenaModeEnv                = "OUROBOROS_ARENA_MODE"
+	spawnSquareRadius           = 2
+	defaultSpawnProtectionTicks = 40
+	SpawnProtectionTicksEnv     = "OUROBOROS_SPAWN_PROTECTION_TICKS"
+	dirtyChunkSize              = 16
+	SnapshotDirEnv              = "OUROBOROS_SNAPSHOT_DIR"
+	DefaultSnapshotDir          = "snapshots"
+	defaultAnsiSnapshotWidth    = 200
+	snapshotCacheDuration       = 10 * time.Second
+	minimapSize                 = 32
+	minimapRefreshTicks         = 14
+	minimapOwnedShare           = 8 // a cell needs 1/8 of it owned to take the owner's color
+	ChatMaxLength               = 120
+	chatBurst                   = 3
+	chatRateWindow              = 5 * time.Second
+	eventFeedBufferSize         = 64
+	eventFeedSize               = 8
+	eventClaimThreshold         = 1000 // smaller loops don't make the feed
+	radarRadius                 = 60
+	historySampleTicks          = 14 // about a second
+	historyMaxSamples           = 240
+	historyMaxMoments           = 50
+	historyBigClaimTiles        = 200
+	homeAssistSearchDepth       = 40
+	SandboxCols                 = 56
+	SandboxRows                 = 15
+	SandboxPlayerColor          = 45
+	SandboxDummyColor           = 203
+	sandboxDummyMoveTicks       = 3 // the dummy is slow enough to catch
+	sandboxDummyRespawnTicks    = 40
+)
+
+// MapColCount and MapRowCount are replaced by the map file's size when one is loaded.
+var (
+	MapColCount = 1000
+	MapRowCount = 1000
 )
 
-var SystemColors = map[int]string{WallColor: "WALL", VoidColor: "void"}
+// WrapAround turns the map into a torus with no border walls, set
+// OUROBOROS_ARENA_MODE=wrap to play it.
+var WrapAround = os.Getenv(ArenaModeEnv) == "wrap"
+
+// spawnProtectionTicks is how long a fresh spawn can't be killed, override it
+// with OUROBOROS_SPAWN_PROTECTION_TICKS (0 turns protection off).
+var spawnProtectionTicks = spawnProtectionTicksFromEnv()
+
+func spawnProtectionTicksFromEnv() int {
+	ticks, err := strconv.Atoi(os.Getenv(SpawnProtectionTicksEnv))
+	if err != nil || ticks < 0 {
+		return defaultSpawnProtectionTicks
+	}
+	return ticks
+}
+
+var SystemColors = map[int]string{WallColor: "WALL", VoidColor: "void", NoBuildColor: "no-build"}
@@ -13,42 +13,7 @@ func (s *DefaultStrategy) getNextBestDirection(player *Player, gm *GameManager)
 	}
 
 	currentTile := player.Location
-	validMoves := make(map[Direction]*Tile)
-
-	for _, dirCoords := range Directions {
-		dx, dy := dirCoords[1], dirCoords[0]
-		nextX := currentTile.X + dx
-		nextY := currentTile.Y + dy
-
-		dir := Direction{Dx: dx, Dy: dy, PlayerColor: *player.Color}
-
-		if dx == -player.CurrentDirection.Dx && dy == -player.CurrentDirection.Dy {
-			continue
-		}
-
-		if IsWall(nextY, nextX) {
-			continue
-		}
-
-		nextTile := gm.GameMap[nextY][nextX]
-
-		isOpponentHead := false
-		gm.Players.Range(func(key, value interface{}) bool {
-			if otherPlayer, ok := value.(*Player); ok && otherPlayer != nil {
-				if key.(int) != *player.Color && otherPlayer.Location == nextTile {
-					isOpponentHead = true
-					return false
-				}
-			}
-			return true
-		})
-
-		if isOpponentHead {
-			continue
-		}
-
-		validMoves[dir] = nextTile
-	}
+	validMoves := getValidMoves(player, gm)
 
 	if len(validMoves) == 0 {
 		return player.CurrentDirection
@@ -127,47 +92,68 @@ func (s *DefaultStrategy) getNextBestDirection(player *Player, gm *GameManager)
 }
 
 func (s *DefaultStrategy) findNearestClaimedTile(start *Tile, playerColor *int, gm *GameManager) *Tile {
-	const maxSearchDepth = 15
+	nearest, _ := s.findNearestOwnedTile(start, playerColor, gm, 15, true, nil)
+	return nearest
+}
 
+// findNearestOwnedTile runs a bounded BFS for the closest tile of playerColor,
+// optionally skipping tail tiles so only settled territory counts. It returns
+// the first step of the way there too, picked from firstSteps when they are
+// given so the way starts with a move the player can make.
+func (s *DefaultStrategy) findNearestOwnedTile(start *Tile, playerColor *int, gm *GameManager, maxSearchDepth int, includeTail bool, firstSteps map[Direction]*Tile) (*Tile, Direction) {
 	q := []*Tile{start}
 	visited := make(map[*Tile]bool)
 	distance := make(map[*Tile]int)
+	firstStep := make(map[*Tile]Direction)
 
 	visited[start] = true
 	distance[start] = 0
 
+	if firstSteps != nil {
+		q = q[:0]
+		for dir, tile := range firstSteps {
+			visited[tile] = true
+			distance[tile] = 1
+			firstStep[tile] = dir
+			q = append(q, tile)
+		}
+	}
+
 	for len(q) > 0 {
 		current := q[0]
 		q = q[1:]
 
 		dist := distance[current]
 		if dist > maxSearchDepth {
-			return nil
+			return nil, Direction{}
 		}
 
-		if current.OwnerColor != nil && *current.OwnerColor == *playerColor {
-			return current
+		if current.OwnerColor != nil && *current.OwnerColor == *playerColor && (includeTail || !current.IsTail) {
+			return current, firstStep[current]
 		}
 
 		for _, dirCoords := range Directions {
 			dx, dy := dirCoords[1], dirCoords[0]
 			nextRow, nextCol := current.Y+dy, current.X+dx
 
-			if IsWall(nextRow, nextCol) {
+			nextTile := tileAt(gm.GameMap, nextRow, nextCol)
+			if nextTile == nil {
 				continue
 			}
 
-			nextTile := gm.GameMap[nextRow][nextCol]
-
 			if _, alreadyVisited := visited[nextTile]; !alreadyVisited {
 				visited[nextTile] = true
 				distance[nextTile] = dist + 1
+				firstStep[nextTile] = firstStep[current]
+				if current == start {
+					firstStep[nextTile] = Direction{Dx: dx, Dy: dy}
+				}
 				q = append(q, nextTile)
 			}
 		}
 	}
 
-	return nil
+	return nil, Direction{}
 }
 
 func (s *DefaultStrategy) getSafestFleeDirection(player *Player, gm *GameManager, validMoves map[Direction]*Tile) Direction {
@@ -251,25 +237,12 @@ func (s *DefaultStrategy) getBestExpansionDirection(player *Player, gm *GameMana
 }
 
 func (s *DefaultStrategy) findNearestOpponentHead(player *Player, gm *GameManager) *Tile {
-	minDist := math.MaxInt32
-	var nearestHead *Tile
-
-	gm.Players.Range(func(key, value interface{}) bool {
-		if otherPlayer, ok := value.(*Player); ok && otherPlayer != nil {
-			if key.(int) == *player.Color {
-				return true
-			}
-
-			dist := GetManhattanDistance(player.Location, otherPlayer.Location)
-			if dist < minDist {
-				minDist = dist
-				nearestHead = otherPlayer.Location
-			}
-		}
-		return true
-	})
+	nearestOpponent, _ := gm.SpatialIndex.NearestHead(player.Location, *player.Color, -1)
+	if nearestOpponent == nil {
+		return nil
+	}
 
-	return nearestHead
+	return nearestOpponent.Location
 }
 
 func (s *DefaultStrategy) calculateThreatScore(player *Player, gm *GameManager) int {
@@ -278,34 +251,23 @@ func (s *DefaultStrategy) calculateThreatScore(player *Player, gm *GameManager)
 		return 0
 	}
 
-	totalThreat := 0
-	gm.Players.Range(func(key, value interface{}) bool {
-		if otherPlayer, ok := value.(*Player); ok && otherPlayer != nil {
-			if key.(int) == *player.Color {
-				return true
-			}
-
-			opponentHead := otherPlayer.Location
+	const threatRadius = 3
 
-			minDistToTail := math.MaxInt32
-			for _, tailTile := range player.Tail.tailTiles {
-				dist := GetManhattanDistance(opponentHead, tailTile)
-				if dist < minDistToTail {
-					minDistToTail = dist
-				}
-			}
-
-			if minDistToTail <= 3 {
-				threatFactor := 4 - minDistToTail
-				totalThreat += 500 * threatFactor
-			}
-		}
-		return true
-	})
+	totalThreat := 0
+	for _, dist := range tailThreats(player, gm, threatRadius) {
+		threatFactor := threatRadius + 1 - dist
+		totalThreat += 500 * threatFactor
+	}
 
 	return totalThreat
 }
 
+// tailThreats maps every rival head within radius of the player's tail to
+// how close it got to it.
+func tailThreats(player *Player, gm *GameManager, radius int) map[*Player]int {
+	return gm.SpatialIndex.HeadsNearTiles(player.Tail.tailTiles, radius, *player.Color)
+}
+
 func (s *DefaultStrategy) estimateTerritoryGain(player *Player) int {
 	tailLength := len(player.Tail.tailTiles)
 	if tailLength < 3 {
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"sync"
+)
+
+type GameEventKind int
+
+const (
+	EventTailCut GameEventKind = iota // Actor cut Victim's tail
+	EventHeadOn                       // Actor and Victim collided head-on
+	EventWall                         // Actor hit the wall
+	EventClaim                        // Actor claimed Tiles in one loop
+)
+
+// GameEvent is one line of the kill feed, Tiles is only set for EventClaim.
+type GameEvent struct {
+	Kind        GameEventKind
+	Tick        uint64
+	ActorName   string
+	ActorColor  int
+	VictimName  string
+	VictimColor int
+	Tiles       int
+}
+
+// EventFeed collects notable events from the game through its own channel and
+// keeps the last few for every session to read, so the feed never competes
+// with the players' update channels.
+type EventFeed struct {
+	EventsChannel chan GameEvent
+	GameManager   *GameManager
+
+	feedLock sync.RWMutex
+	recent   []GameEvent
+}
+
+func NewEventFeed(gameManager *GameManager) *EventFeed {
+	eventFeed := &EventFeed{
+		EventsChannel: make(chan GameEvent, eventFeedBufferSize),
+		GameManager:   gameManager,
+	}
+
+	go eventFeed.eventFeedWorker()
+
+	return eventFeed
+}
+
+func (ef *EventFeed) eventFeedWorker() {
+	for event := range ef.EventsChannel {
+		ef.feedLock.Lock()
+		ef.recent = append(ef.recent, event)
+		if len(ef.recent) > eventFeedSize {
+			ef.recent = ef.recent[len(ef.recent)-eventFeedSize:]
+		}
+		ef.feedLock.Unlock()
+	}
+}
+
+// publish stamps the event with the current tick and never blocks the game,
+// when the feed falls behind events are dropped.
+func (ef *EventFeed) publish(event GameEvent) {
+	event.Tick = ef.GameManager.GetTickSnapshot().Tick
+	select {
+	case ef.EventsChannel <- event:
+	default:
+	}
+}
+
+// Recent returns the latest events, oldest first.
+func (ef *EventFeed) Recent() []GameEvent {
+	ef.feedLock.RLock()
+	defer ef.feedLock.RUnlock()
+
+	recent := make([]GameEvent, len(ef.recent))
+	copy(recent, ef.recent)
+	return recent
+}
@@ -3,9 +3,11 @@ package game
 import (
 	"context"
 	"log"
-	"math"
 	"math/rand"
+	"os"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -22,6 +24,15 @@ type PlayerDeadMsg struct {
 	PlayerColor        int
 	FinalClaimedEstate float64
 	FinalKills         int
+	FinalTilesStolen   int
+	Summary            *LifeSummary
+}
+
+// TerritoryStolenMsg tells a player that someone's loop swallowed their land.
+type TerritoryStolenMsg struct {
+	ThiefName  string
+	ThiefColor int
+	Tiles      int
 }
 
 type ClaimedEstateMsg struct {
@@ -34,9 +45,14 @@ type GameManager struct {
 
 	GameMap [][]*Tile
 
-	SpaceFillerService *SpaceFiller
-	PlayerManager      *PlayerManager
-	DirectionChannel   chan Direction
+	SpaceFillerService   *SpaceFiller
+	PlayerManager        *PlayerManager
+	SpatialIndex         *SpatialIndex
+	PopulationController *PopulationController
+	Territory            *TerritoryCounter
+	Chat                 *ChatService
+	Events               *EventFeed
+	DirectionChannel     chan Direction
 
 	IsRunning     bool
 	MapMutex      sync.RWMutex
@@ -44,6 +60,10 @@ type GameManager struct {
 	GameContext   context.Context
 
 	BotStrategyWg *sync.WaitGroup
+
+	MapVersions  *MapVersions
+	tickCount    uint64
+	tickSnapshot atomic.Pointer[TickSnapshot]
 }
 
 var singletonGameManager *GameManager
@@ -63,8 +83,15 @@ func GetNewGameManager() *GameManager {
 		BotStrategyWg:    &sync.WaitGroup{},
 	}
 	singletonGameManager.GameMap = getInitGameMap()
-	singletonGameManager.SpaceFillerService = getNewSpaceFiller(singletonGameManager.GameMap)
+	singletonGameManager.Territory = territoryCounter
+	singletonGameManager.MapVersions = NewMapVersions()
+	territoryCounter.versions = singletonGameManager.MapVersions
+	singletonGameManager.SpaceFillerService = getNewSpaceFiller(singletonGameManager)
+	singletonGameManager.SpatialIndex = NewSpatialIndex()
 	singletonGameManager.PlayerManager = NewPlayerManager(singletonGameManager)
+	singletonGameManager.PopulationController = NewPopulationController(singletonGameManager)
+	singletonGameManager.Chat = NewChatService(singletonGameManager)
+	singletonGameManager.Events = NewEventFeed(singletonGameManager)
 
 	return singletonGameManager
 }
@@ -89,14 +116,26 @@ func (gm *GameManager) StartGameLoop() {
 	gm.IsRunning = true
 
 	singletonGameManager.intializeBotControledPlayers(botCount)
+	go gm.PopulationController.Run(gm.GameContext)
 
 	ticker := time.NewTicker(GameTickDuration)
 	defer ticker.Stop()
 
+	var roundChannel <-chan time.Time
+	if roundDuration := roundDurationFromEnv(); roundDuration > 0 {
+		roundTicker := time.NewTicker(roundDuration)
+		defer roundTicker.Stop()
+		roundChannel = roundTicker.C
+	}
+
 	for gm.IsRunning {
 		select {
+		case <-roundChannel:
+			gm.ResetRound()
 		case <-ticker.C:
 			gm.processGameTick()
+			gm.tickCount++
+			gm.tickSnapshot.Store(gm.buildTickSnapshot(gm.tickCount))
 			gm.broadcast(GameTickMsg{})
 		case dir := <-gm.DirectionChannel:
 			gm.processPlayerInput(dir)
@@ -105,6 +144,63 @@ func (gm *GameManager) StartGameLoop() {
 	log.Println("Game loop stopped.")
 }
 
+func roundDurationFromEnv() time.Duration {
+	roundMinutes, err := strconv.Atoi(os.Getenv(RoundMinutesEnv))
+	if err != nil || roundMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(roundMinutes) * time.Minute
+}
+
+// ResetRound ends the round for everyone and starts over on a wiped map, a
+// generated map gets a new seed. Bots are benched rather than killed so the
+// reset doesn't cost them lives, then called straight back.
+func (gm *GameManager) ResetRound() {
+	gm.BotStrategyWg.Wait()
+	gm.SpaceFillerService.SpaceFillerWg.Wait()
+
+	gm.Players.Range(func(_, value interface{}) bool {
+		player, ok := value.(*Player)
+		if !ok || player == nil || player.isDead {
+			return true
+		}
+		if player.BotStrategy != nil {
+			gm.PlayerManager.parkBot(player)
+		} else {
+			player.isDead = true
+			gm.PlayerManager.sunsetPlayer(player, true)
+		}
+		return true
+	})
+
+	gm.MapMutex.Lock()
+	for _, row := range gm.GameMap {
+		for _, tile := range row {
+			tile.OwnerColor = nil
+			tile.IsTail = false
+		}
+	}
+	for color := range 256 {
+		gm.Territory.reset(color)
+	}
+	if currentMapInfo.Generated {
+		generateGameMapTerrain(gm.GameMap, rand.Int63())
+	}
+	gm.MapVersions.markAllDirty()
+	gm.MapMutex.Unlock()
+
+	gm.SpatialIndex.Rebuild(&gm.Players)
+	gm.PlayerManager.unparkBots(desiredBotCount(0))
+	log.Printf("Round reset, playing on %s", currentMapInfo.Name)
+}
+
+// MapInfo tells which map is being played and, for generated ones, its seed.
+func (gm *GameManager) MapInfo() MapInfo {
+	gm.MapMutex.RLock()
+	defer gm.MapMutex.RUnlock()
+	return currentMapInfo
+}
+
 func (gm *GameManager) StopGameLoop() {
 	if !gm.IsRunning {
 		return
@@ -128,122 +224,106 @@ func (gm *GameManager) processPlayerInput(dir Direction) {
 func (gm *GameManager) processGameTick() {
 	gm.BotStrategyWg.Wait()
 	gm.SpaceFillerService.SpaceFillerWg.Wait()
+	gm.MapVersions.advance()
+
+	field := &playfield{
+		gameMap:       gm.GameMap,
+		territory:     gm.Territory,
+		tick:          gm.tickCount,
+		playerByColor: gm.getPlayerByColor,
+		endPlayer:     func(player *Player) { gm.PlayerManager.SunsetPlayersChannel <- player },
+		closeLoop:     gm.queueClaim,
+	}
 
+	botPlayers := []*Player{}
+	assistedPlayers := []*Player{}
 	gm.Players.Range(func(key, value interface{}) bool {
 		if player, ok := value.(*Player); ok && player != nil {
 			if player == nil || player.isDead {
 				return true
 			}
 
-			if player.Speed < 0 {
-				if player.ticksSkippedCount < player.Speed*-1 {
-					// we are skipping this tick because we are slow
-					player.ticksSkippedCount += 1
-					return true
-				}
-
-				player.ticksSkippedCount = 0
+			previousLocation := player.Location
+			moved := stepPlayer(field, player)
+			// the head is drawn over the map, so where it was and where it ends up both changed
+			if player.Location != previousLocation {
+				gm.MapVersions.markDirty(previousLocation)
+				gm.MapVersions.markDirty(player.Location)
 			}
-
-			nextTiles := player.GetNextTiles()
-			for _, nextTile := range nextTiles {
-				if IsWall(nextTile.Y, nextTile.X) {
-					player.isDead = true
-					gm.PlayerManager.SunsetPlayersChannel <- player
-					return true
-				}
-
-				player.isSafe = false
-
-				if nextTile.OwnerColor != nil && nextTile.OwnerColor != player.Color {
-					nextTileOwnerAny, _ := gm.Players.Load(*nextTile.OwnerColor)
-					if nextTileOwnerAny == nil {
-						continue
-					}
-
-					nextTileOwner := nextTileOwnerAny.(*Player)
-					if nextTileOwner.isDead || nextTileOwner.isSafe {
-						continue
-					}
-
-					// head to head collision
-					if nextTileOwner.Location == nextTile {
-						nextTileOwner.isDead = true
-						player.isDead = true
-						player.Kills += 1
-						nextTileOwner.Kills += 1
-
-						gm.PlayerManager.SunsetPlayersChannel <- nextTileOwner
-						gm.PlayerManager.SunsetPlayersChannel <- player
-						return true
-					}
-
-					// I'm a killer
-					if nextTile.IsTail {
-						nextTileOwner.isDead = true
-						gm.PlayerManager.SunsetPlayersChannel <- nextTileOwner
-
-						player.Kills += 1
-						nextTile.OwnerColor = player.Color
-						nextTile.IsTail = true
-						player.Tail.tailLock.Lock()
-						player.Tail.tailTiles = append(player.Tail.tailTiles, nextTile)
-						player.Tail.tailLock.Unlock()
-
-						player.Location = nextTile
-						continue
-					}
-
-				}
-
-				if nextTile.OwnerColor == player.Color && len(player.Tail.tailTiles) > 0 {
-					select {
-					case gm.SpaceFillerService.SpaceFillerChan <- player:
-					default:
-						// this is a derpy hack to account for random issue where all spacefillers are dead
-						gm.SpaceFillerService = getNewSpaceFiller(gm.GameMap)
-						log.Printf("space fill channel is full")
-					}
-
-					player.Location = nextTile
-					player.isSafe = true
-					return true
-				}
-
-				if nextTile.OwnerColor != player.Color {
-					nextTile.OwnerColor = player.Color
-					nextTile.IsTail = true
-					nextTile.Direction = player.CurrentDirection
-					player.Tail.tailLock.Lock()
-					player.Tail.tailTiles = append(player.Tail.tailTiles, nextTile)
-					player.Tail.tailLock.Unlock()
-				}
-
-				player.Location = nextTile
+			if !moved {
+				return true
 			}
 
 			if player.BotStrategy != nil {
-				gm.BotStrategyWg.Add(1)
-				go func() {
-					defer gm.BotStrategyWg.Done()
-					nextDirection := player.BotStrategy.getNextBestDirection(player, gm)
-					player.CurrentDirection = nextDirection
-				}()
+				botPlayers = append(botPlayers, player)
+			} else if player.HomeAssist() {
+				assistedPlayers = append(assistedPlayers, player)
 			}
 		}
 		return true
 	})
+
+	// bots decide on the positions everyone ended up in this tick
+	gm.SpatialIndex.Rebuild(&gm.Players)
+
+	for _, player := range botPlayers {
+		if player.isDead {
+			continue
+		}
+		gm.BotStrategyWg.Add(1)
+		go func() {
+			defer gm.BotStrategyWg.Done()
+			nextDirection := player.BotStrategy.getNextBestDirection(player, gm)
+			player.CurrentDirection = nextDirection
+		}()
+	}
+
+	// humans on the home assist are steered like bots, until they take over again
+	for _, player := range assistedPlayers {
+		if player.isDead {
+			continue
+		}
+		gm.BotStrategyWg.Add(1)
+		go func() {
+			defer gm.BotStrategyWg.Done()
+			if nextDirection, steering := gm.homeAssistDirection(player); steering {
+				player.steerHome(nextDirection)
+			}
+		}()
+	}
+}
+
+// queueClaim hands a player that got home to the space fillers.
+func (gm *GameManager) queueClaim(player *Player) {
+	select {
+	case gm.SpaceFillerService.SpaceFillerChan <- player:
+	default:
+		// this is a derpy hack to account for random issue where all spacefillers are dead
+		gm.SpaceFillerService = getNewSpaceFiller(gm)
+		log.Printf("space fill channel is full")
+	}
 }
 
 func (gm *GameManager) CreateNewPlayer(playerName string, playerColor int, userSession ssh.Session) *Player {
-	spawnTile := gm.getSpawnTile()
-	newPlayer := CreateNewPlayer(userSession, playerName, playerColor, spawnTile)
+	// the old holder of the color goes first so its land isn't counted as ours
 	if player, ok := gm.Players.Load(playerColor); ok {
 		gm.PlayerManager.sunsetPlayer(player.(*Player), false)
 	}
-
-	gm.Players.Store(playerColor, newPlayer)
+	spawnTile := gm.getSpawnTile()
+	newPlayer := CreateNewPlayer(userSession, playerName, playerColor, spawnTile)
+	newPlayer.History = newPlayerHistory(gm.GetTickSnapshot().Tick)
+	gm.PlayerManager.markHumanHeld(playerColor)
+
+	// a bot respawning in the color meanwhile makes way as well
+	for {
+		holder, taken := gm.Players.LoadOrStore(playerColor, newPlayer)
+		if !taken {
+			break
+		}
+		gm.PlayerManager.sunsetPlayer(holder.(*Player), false)
+	}
 	gm.SessionsToPlayers.Store(userSession, newPlayer)
+	gm.SpatialIndex.AddHead(newPlayer)
 
 	return newPlayer
 }
@@ -255,37 +335,27 @@ func (gm *GameManager) getSpawnTile() *Tile {
 	)
 
 	bestTile := gm.GameMap[MapRowCount/2][MapColCount/2]
+	if len(spawnZoneTiles) > 0 {
+		bestTile = spawnZoneTiles[0]
+	}
 	maxMinDist := -1
 
 	for range sampleAttempts {
-		row := rand.Intn(MapRowCount-2*safeMargin) + safeMargin
-		col := rand.Intn(MapColCount-2*safeMargin) + safeMargin
-		tile := gm.GameMap[row][col]
+		var tile *Tile
+		if len(spawnZoneTiles) > 0 {
+			tile = spawnZoneTiles[rand.Intn(len(spawnZoneTiles))]
+		} else {
+			row := rand.Intn(MapRowCount-2*safeMargin) + safeMargin
+			col := rand.Intn(MapColCount-2*safeMargin) + safeMargin
+			tile = gm.GameMap[row][col]
+		}
 
-		// Skip occupied or tail tiles
-		if tile.OwnerColor != nil || tile.IsTail {
+		// Skip tiles without room for the starting square
+		if !gm.hasRoomToSpawn(tile) {
 			continue
 		}
 
-		minDist := math.MaxInt32
-
-		// Directly iterate players — no slice needed
-		gm.Players.Range(func(_, value interface{}) bool {
-			p, ok := value.(*Player)
-			if !ok || p == nil {
-				return true
-			}
-
-			d := GetManhattanDistance(tile, p.Location)
-			if d < minDist {
-				minDist = d
-				// Early stop if worse than current best
-				if minDist < maxMinDist {
-					return false
-				}
-			}
-			return true
-		})
+		_, minDist := gm.SpatialIndex.NearestHead(tile, -1, -1)
 
 		if minDist > maxMinDist {
 			maxMinDist = minDist
@@ -296,21 +366,36 @@ func (gm *GameManager) getSpawnTile() *Tile {
 	return bestTile
 }
 
+// hasRoomToSpawn is true when the whole starting square around tile is free
+// ground nobody owns.
+func (gm *GameManager) hasRoomToSpawn(tile *Tile) bool {
+	for row := tile.Y - spawnSquareRadius; row <= tile.Y+spawnSquareRadius; row++ {
+		for col := tile.X - spawnSquareRadius; col <= tile.X+spawnSquareRadius; col++ {
+			squareTile := tileAt(gm.GameMap, row, col)
+			if squareTile == nil || !isFreeSpawnTile(squareTile) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (gm *GameManager) getPlayerByColor(color int) *Player {
+	if playerAny, ok := gm.Players.Load(color); ok {
+		if player, ok := playerAny.(*Player); ok {
+			return player
+		}
+	}
+	return nil
+}
+
 func (gm *GameManager) isOtherPlayerTail(tile *Tile, playerColor *int) bool {
 	return tile.IsTail && tile.OwnerColor != nil && playerColor != tile.OwnerColor
 }
 
-var defaultStrategy = &DefaultStrategy{}
-
 func (gm *GameManager) intializeBotControledPlayers(botCount int) {
-	for botId := 0; botId < botCount; botId++ {
-		if _, ok := SystemColors[botId]; ok {
-			continue
-		}
-
-		botPlayer := CreateNewPlayer(nil, funnyBotNames[botId], botId, gm.getSpawnTile())
-		botPlayer.BotStrategy = defaultStrategy
-		gm.Players.Store(botId, botPlayer)
+	for _, slot := range gm.PlayerManager.initBotSlots(botCount) {
+		gm.PlayerManager.spawnBot(slot)
 	}
 }
 
@@ -318,10 +403,16 @@ func (gm *GameManager) GetMapCopy(startRow, endRow, startCol, endCol int) [][]Ti
 	gm.MapMutex.RLock()
 	defer gm.MapMutex.RUnlock()
 
-	startRow = max(0, startRow)
-	endRow = min(MapRowCount, endRow)
-	startCol = max(0, startCol)
-	endCol = min(MapColCount, endCol)
+	// in wrap-around mode the window may hang over the edge and is folded back
+	if WrapAround {
+		endRow = min(endRow, startRow+MapRowCount)
+		endCol = min(endCol, startCol+MapColCount)
+	} else {
+		startRow = max(0, startRow)
+		endRow = min(MapRowCount, endRow)
+		startCol = max(0, startCol)
+		endCol = min(MapColCount, endCol)
+	}
 
 	rows := endRow - startRow
 	if rows <= 0 {
@@ -337,7 +428,8 @@ func (gm *GameManager) GetMapCopy(startRow, endRow, startCol, endCol int) [][]Ti
 	for i := 0; i < rows; i++ {
 		mapCopy[i] = make([]Tile, cols)
 		for j := 0; j < cols; j++ {
-			mapCopy[i][j] = *gm.GameMap[startRow+i][startCol+j]
+			row, col := WrapCoords(startRow+i, startCol+j)
+			mapCopy[i][j] = *gm.GameMap[row][col]
 		}
 	}
 
@@ -1,11 +1,28 @@
 package game
 
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+type Terrain uint8
+
+const (
+	TerrainOpen    Terrain = iota
+	TerrainWall            // nobody can pass
+	TerrainNoBuild         // you can run through it but never claim it
+	TerrainSpawn           // open ground where new players may appear
+)
+
 type Tile struct {
 	OwnerColor *int
 	IsTail     bool
 	X          int
 	Y          int
 	Direction  Direction
+	Terrain    Terrain
 }
 
 func CreateNewTile(row int, col int) *Tile {
@@ -15,16 +32,46 @@ func CreateNewTile(row int, col int) *Tile {
 		OwnerColor: nil,
 		IsTail:     false,
 		Direction:  Direction{},
+		Terrain:    TerrainOpen,
 	}
 }
 
+// IsBuildable tells whether the tile can become someone's land.
+func (t *Tile) IsBuildable() bool {
+	return t.Terrain != TerrainWall && t.Terrain != TerrainNoBuild
+}
+
 var GameMap [][]*Tile
 
+// spawnZoneTiles holds every TerrainSpawn tile, empty means spawn anywhere.
+var spawnZoneTiles []*Tile
+
+// MapInfo describes the map being played, Seed only means something for
+// generated maps.
+type MapInfo struct {
+	Name      string
+	Seed      int64
+	Generated bool
+}
+
+var currentMapInfo = MapInfo{Name: "Open field"}
+
 func getInitGameMap() [][]*Tile {
 	if GameMap != nil {
 		return GameMap
 	}
 
+	if mapPath := os.Getenv(MapPathEnv); mapPath != "" {
+		mapDefinition, err := LoadMapFile(mapPath)
+		if err == nil {
+			log.Printf("Loaded map %q (%dx%d) from %s", mapDefinition.Name, mapDefinition.Cols, mapDefinition.Rows, mapPath)
+			GameMap = mapDefinition.buildGameMap()
+			currentMapInfo = MapInfo{Name: mapDefinition.Name}
+			return GameMap
+		}
+		log.Printf("Falling back to the empty map: %v", err)
+	}
+
 	GameMap = make([][]*Tile, MapRowCount)
 
 	for row := 0; row < MapRowCount; row++ {
@@ -34,5 +81,39 @@ func getInitGameMap() [][]*Tile {
 		}
 	}
 
+	if seed, ok := mapSeedFromEnv(); ok {
+		generateGameMapTerrain(GameMap, seed)
+	}
+
 	return GameMap
 }
+
+// mapSeedFromEnv reads MapSeedEnv, "random" picks a fresh seed.
+func mapSeedFromEnv() (int64, bool) {
+	seedValue := os.Getenv(MapSeedEnv)
+	if seedValue == "" {
+		return 0, false
+	}
+	if seedValue == "random" {
+		return time.Now().UnixNano(), true
+	}
+
+	seed, err := strconv.ParseInt(seedValue, 10, 64)
+	if err != nil {
+		log.Printf("Ignoring map seed %q: %v", seedValue, err)
+		return 0, false
+	}
+	return seed, true
+}
+
+func generateGameMapTerrain(gameMap [][]*Tile, seed int64) {
+	usedSeed, err := GenerateMapTerrain(gameMap, DefaultMapGeneratorOptions(seed))
+	if err != nil {
+		log.Printf("Falling back to the empty map: %v", err)
+		currentMapInfo = MapInfo{Name: "Open field"}
+		return
+	}
+
+	log.Printf("Generated map with seed %d", usedSeed)
+	currentMapInfo = MapInfo{Name: "Generated", Seed: usedSeed, Generated: true}
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -21,7 +22,61 @@ type Score struct {
 	PlayerName  string
 	ClaimedLand float64 // Updated type to match the REAL type in the database schema
 	Kills       int
+	TilesStolen int
+	Assisted    bool // the auto return home was used during the run
 	CreatedAt   time.Time
+	Rank        int // position in the query's period and order, searches keep it
+}
+
+type ScoreWindow int
+
+const (
+	ScoreWindowToday ScoreWindow = iota
+	ScoreWindowWeek
+	ScoreWindowAllTime
+)
+
+// scoreWindowSQL limits scores to a period, days and weeks start in UTC and
+// weeks on Monday.
+var scoreWindowSQL = map[ScoreWindow]string{
+	ScoreWindowToday:   `created_at >= datetime('now', 'start of day')`,
+	ScoreWindowWeek:    `created_at >= datetime('now', 'start of day', 'weekday 0', '-6 days')`,
+	ScoreWindowAllTime: `1 = 1`,
+}
+
+type ScoreSort int
+
+const (
+	ScoreSortLand ScoreSort = iota
+	ScoreSortKills
+)
+
+var scoreSortSQL = map[ScoreSort]string{
+	ScoreSortLand:  `claimed_land DESC, kills DESC, id`,
+	ScoreSortKills: `kills DESC, claimed_land DESC, id`,
+}
+
+// ScoreQuery picks which scores the leaderboard lists and in what order.
+// NameSearch matches part of the name, ignoring case.
+type ScoreQuery struct {
+	Window     ScoreWindow
+	SortBy     ScoreSort
+	NameSearch string
+}
+
+// rankedSQL numbers every score of the period, the name filter goes on top so
+// a search still shows real ranks.
+func (query ScoreQuery) rankedSQL() string {
+	return `
+	SELECT id, player_name, claimed_land, kills, tiles_stolen, assisted, created_at,
+		ROW_NUMBER() OVER (ORDER BY ` + scoreSortSQL[query.SortBy] + `) AS rank
+	FROM ` + tableName + `
+	WHERE ` + scoreWindowSQL[query.Window]
+}
+
+func (query ScoreQuery) namePattern() string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(query.NameSearch)
+	return "%" + escaped + "%"
 }
 
 func NewHighScoreService() *HighScoreService {
@@ -34,6 +89,12 @@ func NewHighScoreService() *HighScoreService {
 	if err := service.createTable(); err != nil {
 		log.Fatalf("Error creating high scores table: %v", err)
 	}
+	if err := service.migrateTable(); err != nil {
+		log.Fatalf("Error migrating high scores table: %v", err)
+	}
+	if err := service.createIndexes(); err != nil {
+		log.Fatalf("Error indexing high scores table: %v", err)
+	}
 
 	return service
 }
@@ -47,6 +108,9 @@ func (serviceImpl *HighScoreService) createTable() error {
 		player_color INT NOT NULL,
 		claimed_land REAL NOT NULL,
 		kills INTEGER NOT NULL,
+		tiles_stolen INTEGER NOT NULL DEFAULT 0,
+		summary TEXT NOT NULL DEFAULT '',
+		assisted INTEGER NOT NULL DEFAULT 0,
 		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
 	);`
 
@@ -58,15 +122,76 @@ func (serviceImpl *HighScoreService) createTable() error {
 	return nil
 }
 
+// createIndexes backs the leaderboard's periods and orders.
+func (serviceImpl *HighScoreService) createIndexes() error {
+	const createIndexesSQL = `
+	CREATE INDEX IF NOT EXISTS idx_` + tableName + `_created_at ON ` + tableName + ` (created_at);
+	CREATE INDEX IF NOT EXISTS idx_` + tableName + `_claimed_land ON ` + tableName + ` (claimed_land DESC, kills DESC);
+	CREATE INDEX IF NOT EXISTS idx_` + tableName + `_kills ON ` + tableName + ` (kills DESC, claimed_land DESC);`
+
+	_, err := serviceImpl.db.Exec(createIndexesSQL)
+	if err != nil {
+		return fmt.Errorf("failed to create indexes: %w", err)
+	}
+	return nil
+}
+
+// migrateTable adds columns introduced after the table was first created.
+func (serviceImpl *HighScoreService) migrateTable() error {
+	if err := ensureColumn(serviceImpl.db, tableName, "tiles_stolen", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := ensureColumn(serviceImpl.db, tableName, "summary", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	return ensureColumn(serviceImpl.db, tableName, "assisted", "INTEGER NOT NULL DEFAULT 0")
+}
+
+// ensureColumn adds a column to a table created by an older version.
+func ensureColumn(db *sql.DB, table string, columnName string, columnDefinition string) error {
+	rows, err := db.Query(`PRAGMA table_info(` + table + `);`)
+	if err != nil {
+		return fmt.Errorf("failed to read table info: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid, notNull, primaryKey int
+			name, columnType         string
+			defaultValue             sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &primaryKey); err != nil {
+			return fmt.Errorf("failed to scan table info: %w", err)
+		}
+		if name == columnName {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error after iterating table info: %w", err)
+	}
+
+	_, err = db.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + columnName + ` ` + columnDefinition + `;`)
+	if err != nil {
+		return fmt.Errorf("failed to add column %s: %w", columnName, err)
+	}
+	log.Printf("Table %s migrated, added %s.", table, columnName)
+	return nil
+}
+
 func (serviceImpl *HighScoreService) SavePlayersHighScore(playerName string,
 	playerColor int,
 	claimedLand float64,
-	kills int) error {
+	kills int,
+	tilesStolen int,
+	summary string,
+	assisted bool) error {
 	const insertSQL = `
-	INSERT INTO ` + tableName + ` (player_name, player_color, claimed_land, kills) 
-	VALUES (?, ?, ?, ?);`
+	INSERT INTO ` + tableName + ` (player_name, player_color, claimed_land, kills, tiles_stolen, summary, assisted) 
+	VALUES (?, ?, ?, ?, ?, ?, ?);`
 
-	_, err := serviceImpl.db.Exec(insertSQL, playerName, playerColor, claimedLand, kills)
+	_, err := serviceImpl.db.Exec(insertSQL, playerName, playerColor, claimedLand, kills, tilesStolen, summary, assisted)
 	if err != nil {
 		return fmt.Errorf("failed to insert high score for %s: %w", playerName, err)
 	}
@@ -74,15 +199,16 @@ func (serviceImpl *HighScoreService) SavePlayersHighScore(playerName string,
 	return nil
 }
 
-// GetHighScores retrieves a paginated list of scores, ordered by claimed land and kills.
-func (serviceImpl *HighScoreService) GetHighScores(limit, offset int) ([]Score, error) {
-	const selectSQL = `
-	SELECT id, player_name, claimed_land, kills, created_at
-	FROM ` + tableName + `
-	ORDER BY claimed_land DESC, kills DESC 
+// GetHighScores retrieves a paginated list of the scores the query picks, in rank order.
+func (serviceImpl *HighScoreService) GetHighScores(query ScoreQuery, limit, offset int) ([]Score, error) {
+	selectSQL := `
+	SELECT id, player_name, claimed_land, kills, tiles_stolen, assisted, created_at, rank
+	FROM (` + query.rankedSQL() + `)
+	WHERE player_name LIKE ? ESCAPE '\'
+	ORDER BY rank
 	LIMIT ? OFFSET ?;`
 
-	rows, err := serviceImpl.db.Query(selectSQL, limit, offset)
+	rows, err := serviceImpl.db.Query(selectSQL, query.namePattern(), limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query high scores: %w", err)
 	}
@@ -93,7 +219,7 @@ func (serviceImpl *HighScoreService) GetHighScores(limit, offset int) ([]Score,
 	for rows.Next() {
 		var score Score
 		var createdAt string // Read as string from DB
-		err := rows.Scan(&score.ID, &score.PlayerName, &score.ClaimedLand, &score.Kills, &createdAt)
+		err := rows.Scan(&score.ID, &score.PlayerName, &score.ClaimedLand, &score.Kills, &score.TilesStolen, &score.Assisted, &createdAt, &score.Rank)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -115,10 +241,33 @@ func (serviceImpl *HighScoreService) GetHighScores(limit, offset int) ([]Score,
 	return scores, nil
 }
 
-func (serviceImpl *HighScoreService) GetTotalScoreCount() (int, error) {
-	const countSQL = `SELECT COUNT(*) FROM ` + tableName + `;`
+// GetLifeSummary returns the timeline saved with a score, scores from before
+// timelines were kept have none.
+func (serviceImpl *HighScoreService) GetLifeSummary(scoreID int) (LifeSummary, bool, error) {
+	const selectSQL = `SELECT summary FROM ` + tableName + ` WHERE id = ?;`
+
+	var encoded string
+	if err := serviceImpl.db.QueryRow(selectSQL, scoreID).Scan(&encoded); err != nil {
+		return LifeSummary{}, false, fmt.Errorf("failed to load summary of score %d: %w", scoreID, err)
+	}
+	if encoded == "" {
+		return LifeSummary{}, false, nil
+	}
+
+	summary, err := DecodeLifeSummary(encoded)
+	if err != nil {
+		return LifeSummary{}, false, fmt.Errorf("failed to decode summary of score %d: %w", scoreID, err)
+	}
+	return summary, true, nil
+}
+
+func (serviceImpl *HighScoreService) GetTotalScoreCount(query ScoreQuery) (int, error) {
+	countSQL := `
+	SELECT COUNT(*)
+	FROM ` + tableName + `
+	WHERE ` + scoreWindowSQL[query.Window] + ` AND player_name LIKE ? ESCAPE '\';`
 	var count int
-	err := serviceImpl.db.QueryRow(countSQL).Scan(&count)
+	err := serviceImpl.db.QueryRow(countSQL, query.namePattern()).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get total score count: %w", err)
 	}
@@ -143,3 +292,20 @@ func (serviceImpl *HighScoreService) GetPlayerRank(claimedLand float64, kills in
 
 	return rank, nil
 }
+
+// GetPlayerBestRank finds the best rank of the player's scores among the ones
+// the query picks, ignoring its name search.
+func (serviceImpl *HighScoreService) GetPlayerBestRank(query ScoreQuery, playerName string) (int, bool, error) {
+	selectSQL := `
+	SELECT MIN(rank)
+	FROM (` + query.rankedSQL() + `)
+	WHERE player_name = ?;`
+
+	var rank sql.NullInt64
+	err := serviceImpl.db.QueryRow(selectSQL, playerName).Scan(&rank)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get best rank of %s: %w", playerName, err)
+	}
+
+	return int(rank.Int64), rank.Valid, nil
+}
@@ -0,0 +1,53 @@
+package game
+
+// homeAssistDirection picks the way home for a player on the home assist, the
+// way a bot heads home: away from a rival closing in on the tail first, then
+// the shortest way onto settled land. Land further than the search reaches is
+// headed for in a straight line. It turns the assist off once the player is
+// home and reports whether it is still steering.
+func (gm *GameManager) homeAssistDirection(player *Player) (Direction, bool) {
+	if len(player.Tail.tailTiles) == 0 {
+		player.SetHomeAssist(false)
+		return player.CurrentDirection, false
+	}
+
+	validMoves := getValidMoves(player, gm)
+	if len(validMoves) == 0 {
+		return player.CurrentDirection, true
+	}
+
+	if defaultStrategy.calculateThreatScore(player, gm) > 0 {
+		return defaultStrategy.getSafestFleeDirection(player, gm, validMoves), true
+	}
+
+	if home, direction := defaultStrategy.findNearestOwnedTile(player.Location, player.Color, gm, homeAssistSearchDepth, false, validMoves); home != nil {
+		return direction, true
+	}
+
+	if home := nearestSettledTile(player); home != nil {
+		return getDirectionTowards(player, home, validMoves), true
+	}
+
+	// nothing left to return to
+	player.SetHomeAssist(false)
+	return player.CurrentDirection, false
+}
+
+// nearestSettledTile looks through all of the player's land, for when it is
+// too far for a search from the head.
+func nearestSettledTile(player *Player) *Tile {
+	player.AllTiles.allTilesLock.Lock()
+	defer player.AllTiles.allTilesLock.Unlock()
+
+	var nearest *Tile
+	nearestDistance := 0
+	for _, tile := range player.AllTiles.AllPlayerTiles {
+		if tile.OwnerColor != player.Color || tile.IsTail {
+			continue
+		}
+		if distance := GetManhattanDistance(player.Location, tile); nearest == nil || distance < nearestDistance {
+			nearest, nearestDistance = tile, distance
+		}
+	}
+	return nearest
+}
@@ -0,0 +1,146 @@
+package game
+
+import (
+	"math"
+	"sync"
+)
+
+const (
+	hunterSearchRadius  = 40
+	hunterGiveUpRadius  = 60
+	hunterMaxLead       = 12
+	hunterMaxTailLength = 40
+)
+
+// HunterStrategy chases the nearest exposed enemy tail and commits to that
+// victim until it is dead, safe at home or out of reach.
+type HunterStrategy struct {
+	DefaultStrategy
+	PreferHumans bool
+
+	targets sync.Map // hunter color -> victim color
+}
+
+func NewHunterStrategy(preferHumans bool) *HunterStrategy {
+	return &HunterStrategy{PreferHumans: preferHumans}
+}
+
+func (s *HunterStrategy) getNextBestDirection(player *Player, gm *GameManager) Direction {
+	if player.isDead {
+		return Direction{}
+	}
+
+	validMoves := getValidMoves(player, gm)
+	if len(validMoves) == 0 {
+		return player.CurrentDirection
+	}
+
+	for dir, tile := range validMoves {
+		if gm.isOtherPlayerTail(tile, player.Color) {
+			return dir
+		}
+	}
+
+	// a long or threatened tail of our own means it's time to bank it
+	if len(player.Tail.tailTiles) > hunterMaxTailLength || s.calculateThreatScore(player, gm) > 0 {
+		s.targets.Delete(*player.Color)
+		return s.DefaultStrategy.getNextBestDirection(player, gm)
+	}
+
+	victim := s.pickVictim(player, gm)
+	if victim == nil {
+		return s.DefaultStrategy.getNextBestDirection(player, gm)
+	}
+
+	aim := s.predictInterception(player, victim, gm)
+	if aim == nil {
+		return s.DefaultStrategy.getNextBestDirection(player, gm)
+	}
+
+	return getDirectionTowards(player, aim, validMoves)
+}
+
+func (s *HunterStrategy) pickVictim(player *Player, gm *GameManager) *Player {
+	if victimColor, ok := s.targets.Load(*player.Color); ok {
+		victim := gm.getPlayerByColor(victimColor.(int))
+		if victim != nil && !victim.isDead && len(victim.Tail.tailTiles) > 1 &&
+			GetManhattanDistance(player.Location, victim.Location) <= hunterGiveUpRadius {
+			return victim
+		}
+		s.targets.Delete(*player.Color)
+	}
+
+	var bestVictim *Player
+	bestScore := math.MaxInt32
+
+	for _, tailTile := range gm.SpatialIndex.TailsWithin(player.Location, hunterSearchRadius, *player.Color) {
+		if tailTile.OwnerColor == nil {
+			continue
+		}
+
+		owner := gm.getPlayerByColor(*tailTile.OwnerColor)
+		if owner == nil || owner.isDead {
+			continue
+		}
+
+		score := GetManhattanDistance(player.Location, tailTile)
+		if s.PreferHumans && owner.SshSession != nil {
+			score /= 2
+		}
+
+		if score < bestScore {
+			bestScore = score
+			bestVictim = owner
+		}
+	}
+
+	if bestVictim != nil {
+		s.targets.Store(*player.Color, *bestVictim.Color)
+	}
+
+	return bestVictim
+}
+
+// predictInterception aims at the spot in front of the victim that will have
+// become tail by the time we get there, or at its closest existing tail tile.
+func (s *HunterStrategy) predictInterception(player *Player, victim *Player, gm *GameManager) *Tile {
+	var aim *Tile
+	aimDist := math.MaxInt32
+
+	victim.Tail.tailLock.Lock()
+	for _, tailTile := range victim.Tail.tailTiles {
+		if !tailTile.IsTail || tailTile.OwnerColor != victim.Color {
+			continue
+		}
+		dist := GetManhattanDistance(player.Location, tailTile)
+		if dist < aimDist {
+			aimDist = dist
+			aim = tailTile
+		}
+	}
+	victim.Tail.tailLock.Unlock()
+
+	head := victim.Location
+	victimStep := max(1, victim.Speed)
+	for lead := 1; lead <= hunterMaxLead; lead++ {
+		row := head.Y + victim.CurrentDirection.Dy*lead
+		col := head.X + victim.CurrentDirection.Dx*lead
+		tile := tileAt(gm.GameMap, row, col)
+		if tile == nil {
+			break
+		}
+		if tile.OwnerColor == victim.Color {
+			// it's heading home, that part of the path won't be tail
+			break
+		}
+
+		victimTicks := (lead + victimStep - 1) / victimStep
+		dist := GetManhattanDistance(player.Location, tile)
+		if dist > victimTicks && dist < aimDist {
+			aimDist = dist
+			aim = tile
+		}
+	}
+
+	return aim
+}
@@ -0,0 +1,224 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// xtermPalette maps the 256 terminal colors to RGB the way xterm draws them,
+// so exported images look like the game does in a terminal.
+var xtermPalette = buildXtermPalette()
+
+func buildXtermPalette() color.Palette {
+	palette := make(color.Palette, 256)
+
+	standard := [16][3]uint8{
+		{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0},
+		{0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+		{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+		{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+	}
+	for i, rgb := range standard {
+		palette[i] = color.RGBA{R: rgb[0], G: rgb[1], B: rgb[2], A: 255}
+	}
+
+	cubeLevels := [6]uint8{0, 95, 135, 175, 215, 255}
+	for i := 0; i < 216; i++ {
+		palette[16+i] = color.RGBA{R: cubeLevels[i/36], G: cubeLevels[(i/6)%6], B: cubeLevels[i%6], A: 255}
+	}
+
+	for i := 0; i < 24; i++ {
+		gray := uint8(8 + 10*i)
+		palette[232+i] = color.RGBA{R: gray, G: gray, B: gray, A: 255}
+	}
+
+	return palette
+}
+
+// PaletteRGB is the RGB a terminal color is drawn with.
+func PaletteRGB(terminalColor int) (uint8, uint8, uint8) {
+	rgba := xtermPalette[terminalColor].(color.RGBA)
+	return rgba.R, rgba.G, rgba.B
+}
+
+// mapColors returns the color of every tile, row by row: the owner's color
+// for land and tails, the system colors for walls, no-build and void.
+func (gm *GameManager) mapColors() ([]uint8, int, int) {
+	gm.MapMutex.RLock()
+	defer gm.MapMutex.RUnlock()
+
+	rows, cols := len(gm.GameMap), len(gm.GameMap[0])
+	colors := make([]uint8, rows*cols)
+
+	for row := range rows {
+		for col := range cols {
+			tile := gm.GameMap[row][col]
+			switch {
+			case IsWall(row, col):
+				colors[row*cols+col] = WallColor
+			case tile.OwnerColor != nil:
+				colors[row*cols+col] = uint8(*tile.OwnerColor)
+			case tile.Terrain == TerrainNoBuild:
+				colors[row*cols+col] = NoBuildColor
+			default:
+				colors[row*cols+col] = VoidColor
+			}
+		}
+	}
+
+	return colors, rows, cols
+}
+
+// WriteMapPNG renders the whole map as a PNG, one pixel per tile.
+func (gm *GameManager) WriteMapPNG(w io.Writer) error {
+	colors, rows, cols := gm.mapColors()
+
+	img := image.NewPaletted(image.Rect(0, 0, cols, rows), xtermPalette)
+	copy(img.Pix, colors)
+
+	return png.Encode(w, img)
+}
+
+// WriteMapANSI renders the map scaled down to width terminal columns. Every
+// character is a half block, so it covers two rows of blocks, and each block
+// takes the color found most often in it. A width of 0 picks the default.
+func (gm *GameManager) WriteMapANSI(w io.Writer, width int) error {
+	colors, rows, cols := gm.mapColors()
+
+	if width <= 0 {
+		width = defaultAnsiSnapshotWidth
+	}
+	width = min(width, cols)
+	blockSize := (cols + width - 1) / width
+	blockCols := (cols + blockSize - 1) / blockSize
+	blockRows := (rows + blockSize - 1) / blockSize
+
+	blockColor := func(blockRow, blockCol int) uint8 {
+		counts := [256]int{}
+		best := uint8(VoidColor)
+		for row := blockRow * blockSize; row < min(rows, (blockRow+1)*blockSize); row++ {
+			for col := blockCol * blockSize; col < min(cols, (blockCol+1)*blockSize); col++ {
+				tileColor := colors[row*cols+col]
+				counts[tileColor]++
+				if counts[tileColor] > counts[best] {
+					best = tileColor
+				}
+			}
+		}
+		return best
+	}
+
+	out := bufio.NewWriter(w)
+	for blockRow := 0; blockRow < blockRows; blockRow += 2 {
+		for blockCol := range blockCols {
+			bottom := uint8(VoidColor)
+			if blockRow+1 < blockRows {
+				bottom = blockColor(blockRow+1, blockCol)
+			}
+			fmt.Fprintf(out, "\x1b[38;5;%dm\x1b[48;5;%dm▀", blockColor(blockRow, blockCol), bottom)
+		}
+		out.WriteString("\x1b[0m\n")
+	}
+
+	return out.Flush()
+}
+
+type cachedSnapshot struct {
+	data   []byte
+	madeAt time.Time
+}
+
+// snapshotCache keeps recent exports for snapshotCacheDuration, so the ssh
+// commands can't make the server encode the whole map on every call.
+var snapshotCache = struct {
+	cacheLock sync.Mutex
+	entries   map[string]cachedSnapshot
+}{entries: make(map[string]cachedSnapshot)}
+
+// writeCachedSnapshot serves the export under key while it is fresh and
+// makes a new one with write otherwise.
+func writeCachedSnapshot(w io.Writer, key string, write func(io.Writer) error) error {
+	snapshotCache.cacheLock.Lock()
+	defer snapshotCache.cacheLock.Unlock()
+
+	cached, ok := snapshotCache.entries[key]
+	if !ok || time.Since(cached.madeAt) >= snapshotCacheDuration {
+		var buffer bytes.Buffer
+		if err := write(&buffer); err != nil {
+			return err
+		}
+
+		for staleKey, staleSnapshot := range snapshotCache.entries {
+			if time.Since(staleSnapshot.madeAt) >= snapshotCacheDuration {
+				delete(snapshotCache.entries, staleKey)
+			}
+		}
+		cached = cachedSnapshot{data: buffer.Bytes(), madeAt: time.Now()}
+		snapshotCache.entries[key] = cached
+	}
+
+	_, err := w.Write(cached.data)
+	return err
+}
+
+// WriteCachedMapPNG is WriteMapPNG for anyone who asks, at most one encode
+// every snapshotCacheDuration.
+func (gm *GameManager) WriteCachedMapPNG(w io.Writer) error {
+	return writeCachedSnapshot(w, "png", gm.WriteMapPNG)
+}
+
+// WriteCachedMapANSI is WriteMapANSI for anyone who asks, cached per width.
+func (gm *GameManager) WriteCachedMapANSI(w io.Writer, width int) error {
+	if width <= 0 {
+		width = defaultAnsiSnapshotWidth
+	}
+	width = min(width, MapColCount)
+	return writeCachedSnapshot(w, "ansi-"+strconv.Itoa(width), func(w io.Writer) error {
+		return gm.WriteMapANSI(w, width)
+	})
+}
+
+// SaveMapSnapshot writes a PNG and an ANSI dump of the map into dir, named
+// after the current time so a series of them makes a time-lapse.
+func (gm *GameManager) SaveMapSnapshot(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot dir %s: %w", dir, err)
+	}
+
+	baseName := filepath.Join(dir, "snapshot-"+time.Now().Format("20060102-150405"))
+	writers := map[string]func(io.Writer) error{
+		baseName + ".png": gm.WriteMapPNG,
+		baseName + ".ansi": func(w io.Writer) error {
+			return gm.WriteMapANSI(w, 0)
+		},
+	}
+
+	written := []string{}
+	for path, write := range writers {
+		snapshotFile, err := os.Create(path)
+		if err != nil {
+			return written, fmt.Errorf("failed to create snapshot %s: %w", path, err)
+		}
+
+		err = write(snapshotFile)
+		if closeErr := snapshotFile.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return written, fmt.Errorf("failed to write snapshot %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	return written, nil
+}
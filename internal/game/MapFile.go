@@ -0,0 +1,140 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// MapDefinition is a parsed map file. Files look like this:
+//
+//	# comments start with a hash
+//	name Islands
+//	size 1000 1000
+//	scale 20
+//	grid
+//	~~~~~~~~~~
+//	~~SS..~~~~
+//	~~..##~~~~
+//
+// size is cols then rows in tiles. Every grid character covers a scale x scale
+// block of tiles: '.' open, '#' wall, '~' no-build, 'S' spawn region. Missing
+// rows and columns are open ground.
+type MapDefinition struct {
+	Name  string
+	Cols  int
+	Rows  int
+	Scale int
+	Grid  []string
+}
+
+var terrainByRune = map[rune]Terrain{
+	'.': TerrainOpen,
+	' ': TerrainOpen,
+	'#': TerrainWall,
+	'~': TerrainNoBuild,
+	'S': TerrainSpawn,
+}
+
+func LoadMapFile(path string) (*MapDefinition, error) {
+	mapFile, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open map %s: %w", path, err)
+	}
+	defer mapFile.Close()
+
+	mapDefinition := &MapDefinition{Name: path, Cols: MapColCount, Rows: MapRowCount, Scale: 1}
+	inGrid := false
+	lineNumber := 0
+
+	scanner := bufio.NewScanner(mapFile)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if inGrid {
+			for _, r := range line {
+				if _, ok := terrainByRune[r]; !ok {
+					return nil, fmt.Errorf("map %s line %d: unknown terrain %q", path, lineNumber, r)
+				}
+			}
+			mapDefinition.Grid = append(mapDefinition.Grid, line)
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		switch fields[0] {
+		case "name":
+			mapDefinition.Name = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "name"))
+		case "size":
+			if len(fields) != 3 {
+				return nil, fmt.Errorf("map %s line %d: size needs cols and rows", path, lineNumber)
+			}
+			cols, colsErr := strconv.Atoi(fields[1])
+			rows, rowsErr := strconv.Atoi(fields[2])
+			if colsErr != nil || rowsErr != nil || cols < minMapSize || rows < minMapSize {
+				return nil, fmt.Errorf("map %s line %d: size must be two numbers of at least %d", path, lineNumber, minMapSize)
+			}
+			mapDefinition.Cols, mapDefinition.Rows = cols, rows
+		case "scale":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("map %s line %d: scale needs one number", path, lineNumber)
+			}
+			scale, scaleErr := strconv.Atoi(fields[1])
+			if scaleErr != nil || scale < 1 {
+				return nil, fmt.Errorf("map %s line %d: scale must be a positive number", path, lineNumber)
+			}
+			mapDefinition.Scale = scale
+		case "grid":
+			inGrid = true
+		default:
+			return nil, fmt.Errorf("map %s line %d: unknown directive %q", path, lineNumber, fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read map %s: %w", path, err)
+	}
+
+	return mapDefinition, nil
+}
+
+func (md *MapDefinition) terrainAt(row int, col int) Terrain {
+	gridRow, gridCol := row/md.Scale, col/md.Scale
+	if gridRow >= len(md.Grid) {
+		return TerrainOpen
+	}
+
+	line := []rune(md.Grid[gridRow])
+	if gridCol >= len(line) {
+		return TerrainOpen
+	}
+
+	return terrainByRune[line[gridCol]]
+}
+
+// buildGameMap resizes the world to the map and lays out its terrain.
+func (md *MapDefinition) buildGameMap() [][]*Tile {
+	MapColCount, MapRowCount = md.Cols, md.Rows
+	spawnZoneTiles = nil
+
+	gameMap := make([][]*Tile, MapRowCount)
+	for row := 0; row < MapRowCount; row++ {
+		gameMap[row] = make([]*Tile, MapColCount)
+		for col := 0; col < MapColCount; col++ {
+			tile := CreateNewTile(row, col)
+			tile.Terrain = md.terrainAt(row, col)
+			if tile.Terrain == TerrainSpawn {
+				spawnZoneTiles = append(spawnZoneTiles, tile)
+			}
+			gameMap[row][col] = tile
+		}
+	}
+
+	return gameMap
+}
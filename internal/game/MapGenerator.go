@@ -0,0 +1,294 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// MapGeneratorOptions controls how busy a generated map is. The same options
+// and seed always give the same map.
+type MapGeneratorOptions struct {
+	Seed          int64
+	RockDensity   float64 // share of the map covered by rock clusters
+	Corridors     int
+	Rings         int
+	MinOpenShare  float64 // reachable ground needed for the map to be accepted
+	MinSpawnShare float64 // share of tiles with enough room around them to spawn
+}
+
+func DefaultMapGeneratorOptions(seed int64) MapGeneratorOptions {
+	return MapGeneratorOptions{
+		Seed:          seed,
+		RockDensity:   defaultRockDensity,
+		Corridors:     defaultCorridorCount,
+		Rings:         defaultRingCount,
+		MinOpenShare:  0.7,
+		MinSpawnShare: 0.4,
+	}
+}
+
+type mapGenerator struct {
+	gameMap [][]*Tile
+	rows    int
+	cols    int
+	random  *rand.Rand
+}
+
+// GenerateMapTerrain tries the seed and the ones after it until a map passes
+// the reachability and spawn checks. It returns the seed that was used so the
+// map can be replayed.
+func GenerateMapTerrain(gameMap [][]*Tile, options MapGeneratorOptions) (int64, error) {
+	var lastErr error
+	for attempt := int64(0); attempt < maxMapGeneratorAttempts; attempt++ {
+		seedOptions := options
+		seedOptions.Seed = options.Seed + attempt
+
+		if lastErr = generateTerrainWithSeed(gameMap, seedOptions); lastErr == nil {
+			return seedOptions.Seed, nil
+		}
+	}
+
+	clearTerrain(gameMap)
+	return options.Seed, fmt.Errorf("no playable map after %d seeds: %w", maxMapGeneratorAttempts, lastErr)
+}
+
+func clearTerrain(gameMap [][]*Tile) {
+	for _, row := range gameMap {
+		for _, tile := range row {
+			tile.Terrain = TerrainOpen
+		}
+	}
+}
+
+func generateTerrainWithSeed(gameMap [][]*Tile, options MapGeneratorOptions) error {
+	generator := &mapGenerator{
+		gameMap: gameMap,
+		rows:    len(gameMap),
+		cols:    len(gameMap[0]),
+		random:  rand.New(rand.NewSource(options.Seed)),
+	}
+
+	clearTerrain(gameMap)
+	for range options.Rings {
+		generator.addRing()
+	}
+	for range options.Corridors {
+		generator.addCorridor()
+	}
+	generator.addRockClusters(options.RockDensity)
+
+	reachable := generator.sealUnreachablePockets()
+	interior := (generator.rows - 2) * (generator.cols - 2)
+	if float64(reachable) < options.MinOpenShare*float64(interior) {
+		return fmt.Errorf("seed %d: only %d of %d tiles reachable", options.Seed, reachable, interior)
+	}
+
+	spawnable := generator.countSpawnableTiles()
+	if float64(spawnable) < options.MinSpawnShare*float64(interior) {
+		return fmt.Errorf("seed %d: only %d tiles with room to spawn", options.Seed, spawnable)
+	}
+
+	return nil
+}
+
+// setWall turns the tile to rock and reports whether it wasn't rock already.
+func (g *mapGenerator) setWall(row int, col int) bool {
+	if !g.canWall(row, col) || g.gameMap[row][col].Terrain == TerrainWall {
+		return false
+	}
+	g.gameMap[row][col].Terrain = TerrainWall
+	return true
+}
+
+// canWall leaves a strip along the border so nobody spawns boxed in against it.
+func (g *mapGenerator) canWall(row int, col int) bool {
+	return row >= mapGeneratorBorderMargin && col >= mapGeneratorBorderMargin &&
+		row < g.rows-mapGeneratorBorderMargin && col < g.cols-mapGeneratorBorderMargin
+}
+
+// addRing draws a thick square ring with a few gaps to get in and out.
+func (g *mapGenerator) addRing() {
+	radius := 30 + g.random.Intn(60)
+	thickness := 2 + g.random.Intn(2)
+	centerRow := radius + g.random.Intn(max(1, g.rows-2*radius))
+	centerCol := radius + g.random.Intn(max(1, g.cols-2*radius))
+	gapSize := 10 + g.random.Intn(10)
+
+	gaps := []int{}
+	for range 2 + g.random.Intn(3) {
+		gaps = append(gaps, g.random.Intn(8*radius))
+	}
+	isGap := func(position int) bool {
+		for _, gap := range gaps {
+			if position >= gap && position < gap+gapSize {
+				return true
+			}
+		}
+		return false
+	}
+
+	// walk the perimeter once, position counts tiles along it
+	position := 0
+	for side := range 4 {
+		for step := -radius; step < radius; step++ {
+			position++
+			if isGap(position) {
+				continue
+			}
+			for layer := range thickness {
+				offset := radius - layer
+				switch side {
+				case 0:
+					g.setWall(centerRow-offset, centerCol+step)
+				case 1:
+					g.setWall(centerRow+step, centerCol+offset)
+				case 2:
+					g.setWall(centerRow+offset, centerCol-step)
+				case 3:
+					g.setWall(centerRow-step, centerCol-offset)
+				}
+			}
+		}
+	}
+}
+
+// addCorridor draws two parallel walls with a lane between them.
+func (g *mapGenerator) addCorridor() {
+	length := 100 + g.random.Intn(200)
+	width := 8 + g.random.Intn(8)
+	horizontal := g.random.Intn(2) == 0
+
+	startRow := g.random.Intn(g.rows)
+	startCol := g.random.Intn(g.cols)
+	for step := range length {
+		if horizontal {
+			g.setWall(startRow, startCol+step)
+			g.setWall(startRow+width, startCol+step)
+		} else {
+			g.setWall(startRow+step, startCol)
+			g.setWall(startRow+step, startCol+width)
+		}
+	}
+}
+
+// addRockClusters random-walks blobs of rock until density is reached.
+func (g *mapGenerator) addRockClusters(density float64) {
+	// rock only goes where setWall allows, there may be less room than asked for
+	openTiles := 0
+	for row := range g.rows {
+		for col := range g.cols {
+			if g.canWall(row, col) && g.gameMap[row][col].Terrain != TerrainWall {
+				openTiles++
+			}
+		}
+	}
+	targetRocks := min(int(density*float64(g.rows*g.cols)), openTiles)
+	placedRocks := 0
+
+	for placedRocks < targetRocks {
+		row, col := g.random.Intn(g.rows), g.random.Intn(g.cols)
+		for range 50 + g.random.Intn(250) {
+			for _, dir := range Directions {
+				if g.random.Intn(3) != 0 {
+					continue
+				}
+				rockRow, rockCol := row+dir[0], col+dir[1]
+				if rockRow < 0 || rockCol < 0 || rockRow >= g.rows || rockCol >= g.cols {
+					continue
+				}
+				if g.setWall(rockRow, rockCol) {
+					placedRocks++
+				}
+			}
+
+			dir := Directions[g.random.Intn(len(Directions))]
+			row = min(max(row+dir[0], 0), g.rows-1)
+			col = min(max(col+dir[1], 0), g.cols-1)
+		}
+	}
+}
+
+// sealUnreachablePockets keeps the biggest connected open area and turns every
+// pocket that can't be reached from it into rock. Returns the area's size.
+func (g *mapGenerator) sealUnreachablePockets() int {
+	component := make([]int, g.rows*g.cols)
+	componentSizes := []int{0}
+
+	for row := 1; row < g.rows-1; row++ {
+		for col := 1; col < g.cols-1; col++ {
+			if component[row*g.cols+col] != 0 || g.gameMap[row][col].Terrain == TerrainWall {
+				continue
+			}
+
+			componentID := len(componentSizes)
+			componentSizes = append(componentSizes, 0)
+			q := []int{row*g.cols + col}
+			component[row*g.cols+col] = componentID
+
+			for len(q) > 0 {
+				idx := q[0]
+				q = q[1:]
+				componentSizes[componentID]++
+
+				for _, dir := range Directions {
+					nextRow, nextCol := idx/g.cols+dir[0], idx%g.cols+dir[1]
+					if nextRow < 1 || nextCol < 1 || nextRow >= g.rows-1 || nextCol >= g.cols-1 {
+						continue
+					}
+					nextIdx := nextRow*g.cols + nextCol
+					if component[nextIdx] == 0 && g.gameMap[nextRow][nextCol].Terrain != TerrainWall {
+						component[nextIdx] = componentID
+						q = append(q, nextIdx)
+					}
+				}
+			}
+		}
+	}
+
+	biggest := 0
+	for componentID, size := range componentSizes {
+		if size > componentSizes[biggest] {
+			biggest = componentID
+		}
+	}
+
+	for idx, componentID := range component {
+		if componentID != 0 && componentID != biggest {
+			g.gameMap[idx/g.cols][idx%g.cols].Terrain = TerrainWall
+		}
+	}
+
+	return componentSizes[biggest]
+}
+
+// countSpawnableTiles counts open tiles with no rock in the square around them.
+func (g *mapGenerator) countSpawnableTiles() int {
+	const clearance = mapGeneratorSpawnClearance
+
+	// prefix sums of rocks so each square is checked in constant time
+	rocks := make([][]int, g.rows+1)
+	rocks[0] = make([]int, g.cols+1)
+	for row := 0; row < g.rows; row++ {
+		rocks[row+1] = make([]int, g.cols+1)
+		for col := 0; col < g.cols; col++ {
+			isRock := 0
+			if g.gameMap[row][col].Terrain == TerrainWall {
+				isRock = 1
+			}
+			rocks[row+1][col+1] = rocks[row][col+1] + rocks[row+1][col] - rocks[row][col] + isRock
+		}
+	}
+
+	spawnable := 0
+	for row := clearance + 1; row < g.rows-clearance-1; row++ {
+		for col := clearance + 1; col < g.cols-clearance-1; col++ {
+			top, bottom := row-clearance, row+clearance+1
+			left, right := col-clearance, col+clearance+1
+			if rocks[bottom][right]-rocks[top][right]-rocks[bottom][left]+rocks[top][left] == 0 {
+				spawnable++
+			}
+		}
+	}
+
+	return spawnable
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"sync/atomic"
+)
+
+// MapRegion is a rectangle of the map, end row and col are exclusive. In the
+// wrap-around arena it may hang over the edge like the windows GetMapCopy takes.
+type MapRegion struct {
+	StartRow, EndRow int
+	StartCol, EndCol int
+}
+
+// MapVersions splits the map into chunks and remembers the map version each
+// chunk last changed in, so renderers and clients can copy only the parts of
+// their window that changed instead of the whole window every frame.
+type MapVersions struct {
+	version   atomic.Uint64
+	chunkCols int
+	chunkRows int
+	chunks    []atomic.Uint64
+}
+
+func NewMapVersions() *MapVersions {
+	chunkCols := (MapColCount + dirtyChunkSize - 1) / dirtyChunkSize
+	chunkRows := (MapRowCount + dirtyChunkSize - 1) / dirtyChunkSize
+
+	mv := &MapVersions{
+		chunkCols: chunkCols,
+		chunkRows: chunkRows,
+		chunks:    make([]atomic.Uint64, chunkCols*chunkRows),
+	}
+	mv.version.Store(1)
+	return mv
+}
+
+// advance starts a new version, it is bumped once per tick.
+func (mv *MapVersions) advance() {
+	mv.version.Add(1)
+}
+
+func (mv *MapVersions) markDirty(tile *Tile) {
+	if mv == nil || tile == nil {
+		return
+	}
+	mv.chunks[(tile.Y/dirtyChunkSize)*mv.chunkCols+tile.X/dirtyChunkSize].Store(mv.version.Load())
+}
+
+func (mv *MapVersions) markAllDirty() {
+	version := mv.version.Load()
+	for i := range mv.chunks {
+		mv.chunks[i].Store(version)
+	}
+}
+
+// ChangedSince returns the parts of the window whose chunks changed in version
+// or later. Callers keep the Current() they read before copying and pass it
+// back next time, a chunk changed while they copied is simply reported again.
+func (mv *MapVersions) ChangedSince(version uint64, startRow, endRow, startCol, endCol int) []MapRegion {
+	regions := []MapRegion{}
+
+	for regionStartRow := startRow; regionStartRow < endRow; {
+		row, _ := WrapCoords(regionStartRow, 0)
+		regionEndRow := min(endRow, regionStartRow+dirtyChunkSize-row%dirtyChunkSize)
+
+		for regionStartCol := startCol; regionStartCol < endCol; {
+			_, col := WrapCoords(0, regionStartCol)
+			regionEndCol := min(endCol, regionStartCol+dirtyChunkSize-col%dirtyChunkSize)
+
+			if mv.chunks[(row/dirtyChunkSize)*mv.chunkCols+col/dirtyChunkSize].Load() >= version {
+				regions = append(regions, MapRegion{
+					StartRow: regionStartRow, EndRow: regionEndRow,
+					StartCol: regionStartCol, EndCol: regionEndCol,
+				})
+			}
+			regionStartCol = regionEndCol
+		}
+		regionStartRow = regionEndRow
+	}
+
+	return regions
+}
+
+// Current is the version changes are being stamped with right now.
+func (mv *MapVersions) Current() uint64 {
+	return mv.version.Load()
+}
@@ -0,0 +1,95 @@
+package game
+
+// Minimap is the whole world shrunk to a small grid of cells, rebuilt every
+// minimapRefreshTicks as part of the tick snapshot so sessions only read it.
+type Minimap struct {
+	Cols  int
+	Rows  int
+	cells []uint8
+	// presence has a bit per cell for every color owning land or tail in it
+	presence map[int][]uint64
+}
+
+func (gm *GameManager) buildMinimap() *Minimap {
+	colors, mapRows, mapCols := gm.mapColors()
+
+	minimap := &Minimap{
+		Cols:     minimapSize,
+		Rows:     min(2*minimapSize, max(2, minimapSize*mapRows/mapCols)),
+		presence: make(map[int][]uint64),
+	}
+	minimap.cells = make([]uint8, minimap.Cols*minimap.Rows)
+
+	for row := range minimap.Rows {
+		for col := range minimap.Cols {
+			cell := row*minimap.Cols + col
+			counts := [256]int{}
+
+			for mapRow := row * mapRows / minimap.Rows; mapRow < (row+1)*mapRows/minimap.Rows; mapRow++ {
+				for mapCol := col * mapCols / minimap.Cols; mapCol < (col+1)*mapCols/minimap.Cols; mapCol++ {
+					counts[colors[mapRow*mapCols+mapCol]]++
+				}
+			}
+
+			minimap.cells[cell] = minimap.dominantColor(cell, &counts)
+		}
+	}
+
+	return minimap
+}
+
+// dominantColor picks the owner with the most land in the cell, as long as
+// the cell isn't mostly empty, and records everyone present on the way.
+func (mm *Minimap) dominantColor(cell int, counts *[256]int) uint8 {
+	total, owned := 0, 0
+	dominant := -1
+	for color, count := range counts {
+		total += count
+		if count == 0 {
+			continue
+		}
+		if _, ok := SystemColors[color]; ok {
+			continue
+		}
+
+		owned += count
+		if mm.presence[color] == nil {
+			mm.presence[color] = make([]uint64, (len(mm.cells)+63)/64)
+		}
+		mm.presence[color][cell/64] |= 1 << (cell % 64)
+
+		if dominant < 0 || count > counts[dominant] {
+			dominant = color
+		}
+	}
+
+	switch {
+	case dominant >= 0 && owned*minimapOwnedShare >= total:
+		return uint8(dominant)
+	case counts[WallColor]*2 >= total:
+		return WallColor
+	case counts[NoBuildColor]*2 >= total:
+		return NoBuildColor
+	default:
+		return VoidColor
+	}
+}
+
+func (mm *Minimap) Color(row int, col int) int {
+	return int(mm.cells[row*mm.Cols+col])
+}
+
+// HasColor tells whether color owns anything in the cell.
+func (mm *Minimap) HasColor(row int, col int, color int) bool {
+	bits, ok := mm.presence[color]
+	if !ok {
+		return false
+	}
+	cell := row*mm.Cols + col
+	return bits[cell/64]&(1<<(cell%64)) != 0
+}
+
+// CellOf returns the cell a map tile falls into.
+func (mm *Minimap) CellOf(tile *Tile) (int, int) {
+	return min(mm.Rows-1, tile.Y*mm.Rows/MapRowCount), min(mm.Cols-1, tile.X*mm.Cols/MapColCount)
+}
@@ -9,8 +9,9 @@ import (
 )
 
 type Tail struct {
-	tailLock  sync.Mutex
-	tailTiles []*Tile
+	tailLock   sync.Mutex
+	tailTiles  []*Tile
+	stolenFrom map[*Tile]int // tail tiles laid over other players' land, by previous owner
 }
 
 type AllTiles struct {
@@ -28,17 +29,22 @@ type Player struct {
 	UpdateChannel     chan tea.Msg
 	BotStrategy       Strategy
 	Kills             int
+	TilesStolen       int
 	isDead            bool
 	isSafe            bool
+	protectedTicks    int // spawn protection left, counted down every tick
+	deathEvent        *GameEvent
 	Speed             int
 	ticksSkippedCount int //this is used if speed is below 0
 	Tail              Tail
 	AllTiles          AllTiles
+	History           *PlayerHistory // humans only
+	homeAssistLock    sync.Mutex
+	homeAssist        bool // the game steers the player home
+	Assisted          bool // the home assist took over at least once this life
 }
 
 func CreateNewPlayer(sshSession ssh.Session, name string, color int, spawnPoint *Tile) *Player {
-	spawnPoint.OwnerColor = &color
-	spawnPoint.IsTail = true
 	possibleDirections := []Direction{
 		{Dx: 1, Dy: 0},
 		{Dx: 0, Dy: 1},
@@ -46,26 +52,58 @@ func CreateNewPlayer(sshSession ssh.Session, name string, color int, spawnPoint
 		{Dx: 0, Dy: -1},
 	}
 
-	return &Player{
-		Name:             name,
-		Color:            &color,
-		SshSession:       sshSession,
-		Location:         spawnPoint,
-		CurrentDirection: possibleDirections[rand.Intn(len(possibleDirections))],
-		Tail: Tail{
-			tailTiles: []*Tile{
-				spawnPoint,
-			}},
+	player := &Player{
+		Name:              name,
+		Color:             &color,
+		SshSession:        sshSession,
+		Location:          spawnPoint,
+		CurrentDirection:  possibleDirections[rand.Intn(len(possibleDirections))],
 		UpdateChannel:     make(chan tea.Msg, 256),
 		Kills:             0,
 		isDead:            false,
 		isSafe:            false,
+		protectedTicks:    spawnProtectionTicks,
 		Speed:             0,
 		ticksSkippedCount: 0,
 	}
+	player.claimSpawnSquare(spawnPoint)
+
+	return player
+}
+
+// claimSpawnSquare gives the player the free tiles around its spawn point so
+// it starts out with a home to come back to.
+func (p *Player) claimSpawnSquare(spawnPoint *Tile) {
+	gameMap := getInitGameMap()
+	for row := spawnPoint.Y - spawnSquareRadius; row <= spawnPoint.Y+spawnSquareRadius; row++ {
+		for col := spawnPoint.X - spawnSquareRadius; col <= spawnPoint.X+spawnSquareRadius; col++ {
+			tile := tileAt(gameMap, row, col)
+			if tile == nil || (tile != spawnPoint && !isFreeSpawnTile(tile)) {
+				continue
+			}
+			territoryCounter.setTileOwner(tile, p.Color, false)
+			p.AllTiles.AllPlayerTiles = append(p.AllTiles.AllPlayerTiles, tile)
+		}
+	}
+}
+
+func isFreeSpawnTile(tile *Tile) bool {
+	return tile.OwnerColor == nil && !tile.IsTail && tile.IsBuildable()
+}
+
+// IsProtected is true while a fresh spawn can't be killed.
+func (p *Player) IsProtected() bool {
+	return p.protectedTicks > 0
 }
 
 func (p *Player) GetNextTiles() []*Tile {
+	return p.nextTilesOn(getInitGameMap())
+}
+
+// nextTilesOn is GetNextTiles for any map, going off an edge comes back in on
+// the other side.
+func (p *Player) nextTilesOn(gameMap [][]*Tile) []*Tile {
+	rows, cols := len(gameMap), len(gameMap[0])
 	tilesToGet := max(1, p.Speed)
 	currLocationX := p.Location.X
 	currLocationY := p.Location.Y
@@ -76,18 +114,18 @@ func (p *Player) GetNextTiles() []*Tile {
 		nextY := currLocationY + p.CurrentDirection.Dy
 
 		if nextX < 0 {
-			nextX = MapColCount - 1
-		} else if nextX >= MapColCount {
+			nextX = cols - 1
+		} else if nextX >= cols {
 			nextX = 0
 		}
 		if nextY < 0 {
-			nextY = MapRowCount - 1
-		} else if nextY >= MapRowCount {
+			nextY = rows - 1
+		} else if nextY >= rows {
 			nextY = 0
 		}
 
-		if nextY < MapRowCount && nextX < MapColCount {
-			result = append(result, getInitGameMap()[nextY][nextX])
+		if nextY < rows && nextX < cols {
+			result = append(result, gameMap[nextY][nextX])
 			currLocationX = nextX
 			currLocationY = nextY
 		}
@@ -101,6 +139,7 @@ func (p *Player) resetTailData() {
 	defer p.Tail.tailLock.Unlock()
 
 	p.Tail.tailTiles = []*Tile{}
+	p.Tail.stolenFrom = nil
 }
 
 func (p *Player) UpdateDirection(newDir Direction) {
@@ -110,20 +149,51 @@ func (p *Player) UpdateDirection(newDir Direction) {
 	p.CurrentDirection = newDir
 }
 
+// GetConsolidateTiles drops lost and duplicate tiles from AllPlayerTiles and
+// returns how many are left. The land count itself lives in TerritoryCounter.
 func (p *Player) GetConsolidateTiles() float64 {
 	p.AllTiles.allTilesLock.Lock()
 	defer p.AllTiles.allTilesLock.Unlock()
+	return float64(p.consolidateTilesLocked())
+}
+
+func (p *Player) consolidateTilesLocked() int {
 	updatedTiles := []*Tile{}
-	claimedLand := 0.0
+	seenTiles := make(map[*Tile]bool)
 	for _, tile := range p.AllTiles.AllPlayerTiles {
-		if tile.OwnerColor == p.Color {
-			claimedLand += 1.0
+		if tile.OwnerColor == p.Color && !seenTiles[tile] {
+			seenTiles[tile] = true
 			updatedTiles = append(updatedTiles, tile)
 		}
 	}
 
 	p.AllTiles.AllPlayerTiles = updatedTiles
-	return claimedLand
+	return len(updatedTiles)
+}
+
+// SetHomeAssist turns the auto return home on or off. Turning it on only does
+// something out on a tail, at home there is nowhere to return to.
+func (p *Player) SetHomeAssist(on bool) {
+	p.homeAssistLock.Lock()
+	defer p.homeAssistLock.Unlock()
+	p.homeAssist = on
+}
+
+func (p *Player) HomeAssist() bool {
+	p.homeAssistLock.Lock()
+	defer p.homeAssistLock.Unlock()
+	return p.homeAssist
+}
+
+// steerHome turns the player unless the assist was switched off meanwhile,
+// the check and the turn share the lock so a key pressed in between wins.
+func (p *Player) steerHome(direction Direction) {
+	p.homeAssistLock.Lock()
+	defer p.homeAssistLock.Unlock()
+	if p.homeAssist {
+		p.Assisted = true
+		p.CurrentDirection = direction
+	}
 }
 
 func (p *Player) ResetSpeed() {
@@ -0,0 +1,177 @@
+package game
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+type MomentKind int
+
+const (
+	MomentKill  MomentKind = iota // Other's tail was cut
+	MomentClaim                   // a loop claimed Tiles
+	MomentDeath                   // the killing blow, Other is empty for a wall
+)
+
+// Moment is a key point of a life, Tick counts from the spawn.
+type Moment struct {
+	Kind       MomentKind
+	Tick       uint64
+	Tiles      int
+	OtherName  string
+	OtherColor int
+	HeadOn     bool // the death took Other down too
+}
+
+// PlayerHistory follows a human player from spawn to death, bots have none
+// and recording on a nil history does nothing. Territory is sampled every
+// sampleTicks, when the samples fill up neighbours are merged and the
+// interval doubles, so a long life still fits.
+type PlayerHistory struct {
+	historyLock   sync.Mutex
+	spawnTick     uint64
+	lastTick      uint64
+	sampleTicks   uint64
+	territory     []int
+	peakTerritory int
+	longestTail   int
+	loopsClosed   int
+	moments       []Moment
+	killer        *Moment
+}
+
+// LifeSummary is what the game over screen shows and the score keeps.
+type LifeSummary struct {
+	Territory     []int
+	SampleTicks   uint64
+	PeakTerritory int
+	LongestTail   int
+	LoopsClosed   int
+	Kills         int
+	TilesStolen   int
+	TimeAlive     time.Duration
+	Moments       []Moment
+}
+
+func newPlayerHistory(spawnTick uint64) *PlayerHistory {
+	return &PlayerHistory{spawnTick: spawnTick, lastTick: spawnTick, sampleTicks: historySampleTicks}
+}
+
+// sample runs once a tick with the player's land and tail length.
+func (ph *PlayerHistory) sample(tick uint64, territory int, tailLength int) {
+	if ph == nil {
+		return
+	}
+	ph.historyLock.Lock()
+	defer ph.historyLock.Unlock()
+
+	ph.lastTick = tick
+	ph.peakTerritory = max(ph.peakTerritory, territory)
+	ph.longestTail = max(ph.longestTail, tailLength)
+
+	if (tick-ph.spawnTick)%ph.sampleTicks != 0 {
+		return
+	}
+	ph.territory = append(ph.territory, territory)
+	if len(ph.territory) < historyMaxSamples {
+		return
+	}
+
+	// peaks are what the chart is about, merged samples keep the bigger one
+	merged := ph.territory[:0]
+	for i := 0; i+1 < len(ph.territory); i += 2 {
+		merged = append(merged, max(ph.territory[i], ph.territory[i+1]))
+	}
+	ph.territory = merged
+	ph.sampleTicks *= 2
+}
+
+func (ph *PlayerHistory) addMoment(tick uint64, moment Moment) {
+	moment.Tick = tick - min(tick, ph.spawnTick)
+	if len(ph.moments) >= historyMaxMoments {
+		ph.moments = ph.moments[1:]
+	}
+	ph.moments = append(ph.moments, moment)
+}
+
+func (ph *PlayerHistory) recordKill(tick uint64, victim *Player) {
+	if ph == nil {
+		return
+	}
+	ph.historyLock.Lock()
+	defer ph.historyLock.Unlock()
+	ph.addMoment(tick, Moment{Kind: MomentKill, OtherName: victim.Name, OtherColor: *victim.Color})
+}
+
+// recordClaim counts every closed loop, only big ones become moments.
+func (ph *PlayerHistory) recordClaim(tick uint64, tiles int) {
+	if ph == nil {
+		return
+	}
+	ph.historyLock.Lock()
+	defer ph.historyLock.Unlock()
+	ph.loopsClosed++
+	if tiles >= historyBigClaimTiles {
+		ph.addMoment(tick, Moment{Kind: MomentClaim, Tiles: tiles})
+	}
+}
+
+// recordDeath keeps the killing blow, killer is nil when nobody dealt it.
+func (ph *PlayerHistory) recordDeath(tick uint64, killer *Player, headOn bool) {
+	if ph == nil {
+		return
+	}
+	ph.historyLock.Lock()
+	defer ph.historyLock.Unlock()
+	death := Moment{Kind: MomentDeath, OtherColor: -1, HeadOn: headOn}
+	if killer != nil {
+		death.OtherName, death.OtherColor = killer.Name, *killer.Color
+	}
+	death.Tick = tick - min(tick, ph.spawnTick)
+	ph.killer = &death
+}
+
+func (ph *PlayerHistory) summary(player *Player) LifeSummary {
+	ph.historyLock.Lock()
+	defer ph.historyLock.Unlock()
+
+	moments := append([]Moment{}, ph.moments...)
+	if ph.killer != nil {
+		moments = append(moments, *ph.killer)
+	}
+
+	return LifeSummary{
+		Territory:     append([]int{}, ph.territory...),
+		SampleTicks:   ph.sampleTicks,
+		PeakTerritory: ph.peakTerritory,
+		LongestTail:   ph.longestTail,
+		LoopsClosed:   ph.loopsClosed,
+		Kills:         player.Kills,
+		TilesStolen:   player.TilesStolen,
+		TimeAlive:     time.Duration(ph.lastTick-ph.spawnTick) * GameTickDuration,
+		Moments:       moments,
+	}
+}
+
+// Killer is the moment of the killing blow, if the player died.
+func (ls LifeSummary) Killer() (Moment, bool) {
+	for _, moment := range ls.Moments {
+		if moment.Kind == MomentDeath {
+			return moment, true
+		}
+	}
+	return Moment{}, false
+}
+
+// Encode is how the summary is stored next to the score.
+func (ls LifeSummary) Encode() (string, error) {
+	encoded, err := json.Marshal(ls)
+	return string(encoded), err
+}
+
+func DecodeLifeSummary(encoded string) (LifeSummary, error) {
+	var summary LifeSummary
+	err := json.Unmarshal([]byte(encoded), &summary)
+	return summary, err
+}
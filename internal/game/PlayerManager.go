@@ -2,6 +2,9 @@ package game
 
 import (
 	"log"
+	"sort"
+	"sync"
+	"time"
 )
 
 type PlayerManager struct {
@@ -10,6 +13,10 @@ type PlayerManager struct {
 
 	HighScoreService *HighScoreService
 	GameManager      *GameManager
+	BotRoster        *BotRoster
+
+	botSlotsLock sync.Mutex
+	botSlots     map[int]*botSlot
 }
 
 var playerManager *PlayerManager
@@ -24,6 +31,8 @@ func NewPlayerManager(gameManager *GameManager) *PlayerManager {
 		PlayerRebirth:        make(chan int, 1),
 		HighScoreService:     NewHighScoreService(),
 		GameManager:          gameManager,
+		BotRoster:            loadBotRoster(),
+		botSlots:             make(map[int]*botSlot),
 	}
 
 	for w := 1; w <= sunsetWorkersCount; w++ {
@@ -50,7 +59,8 @@ func (sunsetterInst *PlayerManager) sunsetPlayersWorker() {
 }
 
 func (playerManagerInst *PlayerManager) sunsetPlayer(player *Player, needRebirth bool) {
-	playerFinalClaimedLand := 0.0
+	territory := playerManagerInst.GameManager.Territory
+	playerFinalClaimedLand := float64(territory.Get(*player.Color))
 	player.AllTiles.allTilesLock.Lock()
 	player.Tail.tailLock.Lock()
 	defer player.AllTiles.allTilesLock.Unlock()
@@ -58,25 +68,45 @@ func (playerManagerInst *PlayerManager) sunsetPlayer(player *Player, needRebirth
 
 	for _, tile := range player.AllTiles.AllPlayerTiles {
 		if tile.OwnerColor == player.Color {
-			playerFinalClaimedLand += 1.0
-			tile.OwnerColor = nil
+			territory.setTileOwner(tile, nil, false)
 		}
 	}
 	for _, tile := range player.Tail.tailTiles {
 		if tile.OwnerColor == player.Color {
-			tile.OwnerColor = nil
+			territory.setTileOwner(tile, nil, false)
 		}
 	}
 
+	if player.Location.OwnerColor == player.Color {
+		territory.setTileOwner(player.Location, nil, false)
+	}
 	player.Location.IsTail = false
-	player.Location.OwnerColor = nil
+
+	if player.deathEvent != nil {
+		playerManagerInst.GameManager.Events.publish(*player.deathEvent)
+		player.deathEvent = nil
+	}
 
 	if player.SshSession != nil {
+		var summary *LifeSummary
+		encodedSummary := ""
+		if player.History != nil {
+			lifeSummary := player.History.summary(player)
+			summary = &lifeSummary
+			var encodeError error
+			if encodedSummary, encodeError = lifeSummary.Encode(); encodeError != nil {
+				log.Printf("Life summary encode err: %v ", encodeError)
+			}
+		}
+
 		highScoreError := playerManagerInst.HighScoreService.SavePlayersHighScore(
 			player.Name,
 			*player.Color,
-			(playerFinalClaimedLand*100)/float64(MapColCount*MapRowCount),
+			LandPercent(playerFinalClaimedLand),
 			player.Kills,
+			player.TilesStolen,
+			encodedSummary,
+			player.Assisted,
 		)
 
 		if highScoreError != nil {
@@ -87,10 +117,13 @@ func (playerManagerInst *PlayerManager) sunsetPlayer(player *Player, needRebirth
 			PlayerColor:        *player.Color,
 			FinalClaimedEstate: playerFinalClaimedLand,
 			FinalKills:         player.Kills,
+			FinalTilesStolen:   player.TilesStolen,
+			Summary:            summary,
 		}
 	}
 
-	playerManagerInst.GameManager.Players.Delete(*player.Color)
+	// a newcomer may already hold the color
+	playerManagerInst.GameManager.Players.CompareAndDelete(*player.Color, player)
 
 	if needRebirth {
 		playerManagerInst.PlayerRebirth <- *player.Color
@@ -103,12 +136,149 @@ func (playerManagerInst *PlayerManager) rebirthPlayersWorker() {
 		if !ok {
 			return
 		}
-		if playerColorInt != 0 {
-			botPlayer := CreateNewPlayer(nil, funnyBotNames[playerColorInt], playerColorInt,
-				playerManagerInst.GameManager.getSpawnTile())
 
-			botPlayer.BotStrategy = defaultStrategy
-			playerManagerInst.GameManager.Players.Store(playerColorInt, botPlayer)
+		playerManagerInst.botSlotsLock.Lock()
+		slot, hasSlot := playerManagerInst.botSlots[playerColorInt]
+		if !hasSlot || slot.state == botSlotOutOfLives || slot.state == botSlotParked {
+			playerManagerInst.botSlotsLock.Unlock()
+			continue
 		}
+
+		// a human dying in the color doesn't cost the bot a life
+		if slot.state != botSlotHumanHeld {
+			slot.recordDeath()
+		}
+		if !slot.hasLivesLeft() {
+			slot.state = botSlotOutOfLives
+			playerManagerInst.botSlotsLock.Unlock()
+			log.Printf("Bot %s is out of lives", slot.profile.Name)
+			continue
+		}
+
+		// the population controller will bring it back when there's room
+		slot.state = botSlotParked
+		playerManagerInst.botSlotsLock.Unlock()
+
+		if !playerManagerInst.GameManager.PopulationController.shouldRespawn() {
+			continue
+		}
+
+		playerManagerInst.scheduleRespawn(slot)
+	}
+}
+
+// initBotSlots binds the roster to colors, it only happens once per server.
+func (playerManagerInst *PlayerManager) initBotSlots(botCount int) []*botSlot {
+	playerManagerInst.botSlotsLock.Lock()
+	defer playerManagerInst.botSlotsLock.Unlock()
+
+	slots := playerManagerInst.BotRoster.assignColors(botCount)
+	for _, slot := range slots {
+		playerManagerInst.botSlots[slot.color] = slot
+	}
+
+	return slots
+}
+
+func (playerManagerInst *PlayerManager) scheduleRespawn(slot *botSlot) {
+	playerManagerInst.botSlotsLock.Lock()
+	if slot.state != botSlotParked {
+		playerManagerInst.botSlotsLock.Unlock()
+		return
+	}
+	slot.state = botSlotRespawning
+	respawnDelay := playerManagerInst.BotRoster.respawnDelay(slot)
+	playerManagerInst.botSlotsLock.Unlock()
+
+	time.AfterFunc(respawnDelay, func() {
+		playerManagerInst.spawnBot(slot)
+	})
+}
+
+func (playerManagerInst *PlayerManager) spawnBot(slot *botSlot) {
+	gm := playerManagerInst.GameManager
+
+	playerManagerInst.botSlotsLock.Lock()
+	defer playerManagerInst.botSlotsLock.Unlock()
+
+	// a human took the color while the bot was waiting to respawn
+	if slot.state == botSlotHumanHeld {
+		return
+	}
+	if _, taken := gm.Players.Load(slot.color); taken {
+		return
+	}
+
+	botPlayer := CreateNewPlayer(nil, slot.profile.Name, slot.color, gm.getSpawnTile())
+	botPlayer.BotStrategy = slot.strategy()
+	// a human joining in the color wins, the bot gives its square back
+	if _, taken := gm.Players.LoadOrStore(slot.color, botPlayer); taken {
+		for _, tile := range botPlayer.AllTiles.AllPlayerTiles {
+			if tile.OwnerColor == botPlayer.Color {
+				gm.Territory.setTileOwner(tile, nil, false)
+			}
+		}
+		return
+	}
+	gm.SpatialIndex.AddHead(botPlayer)
+	slot.state = botSlotAlive
+	slot.spawnedAt = time.Now()
+}
+
+// markHumanHeld records that a human took over a bot's color.
+func (playerManagerInst *PlayerManager) markHumanHeld(color int) {
+	playerManagerInst.botSlotsLock.Lock()
+	defer playerManagerInst.botSlotsLock.Unlock()
+
+	if slot, ok := playerManagerInst.botSlots[color]; ok {
+		slot.state = botSlotHumanHeld
+	}
+}
+
+// parkBot takes a live bot off the map without costing it a life.
+func (playerManagerInst *PlayerManager) parkBot(bot *Player) {
+	playerManagerInst.botSlotsLock.Lock()
+	slot, ok := playerManagerInst.botSlots[*bot.Color]
+	if !ok || slot.state != botSlotAlive {
+		playerManagerInst.botSlotsLock.Unlock()
+		return
+	}
+	slot.state = botSlotParked
+	playerManagerInst.botSlotsLock.Unlock()
+
+	bot.isDead = true
+	playerManagerInst.sunsetPlayer(bot, false)
+}
+
+func (playerManagerInst *PlayerManager) countSlots(state botSlotState) int {
+	playerManagerInst.botSlotsLock.Lock()
+	defer playerManagerInst.botSlotsLock.Unlock()
+
+	count := 0
+	for _, slot := range playerManagerInst.botSlots {
+		if slot.state == state {
+			count++
+		}
+	}
+	return count
+}
+
+// unparkBots schedules up to count benched bots to come back, lowest colors first.
+func (playerManagerInst *PlayerManager) unparkBots(count int) {
+	playerManagerInst.botSlotsLock.Lock()
+	parked := []*botSlot{}
+	for _, slot := range playerManagerInst.botSlots {
+		if slot.state == botSlotParked {
+			parked = append(parked, slot)
+		}
+	}
+	playerManagerInst.botSlotsLock.Unlock()
+
+	sort.Slice(parked, func(i, j int) bool {
+		return parked[i].color < parked[j].color
+	})
+
+	for _, slot := range parked[:min(count, len(parked))] {
+		playerManagerInst.scheduleRespawn(slot)
 	}
 }
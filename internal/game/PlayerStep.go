@@ -0,0 +1,149 @@
+package game
+
+// playfield is what a player's step plays on: the game's map or a tutorial
+// sandbox. The step owns the rules, the playfield decides what happens to the
+// players they end and to the loops they close.
+type playfield struct {
+	gameMap   [][]*Tile
+	territory *TerritoryCounter
+	tick      uint64
+
+	// playerByColor finds whoever holds a color, nil when nobody does
+	playerByColor func(color int) *Player
+	// endPlayer takes away a player the step killed, it is already marked dead
+	endPlayer func(player *Player)
+	// closeLoop claims the tail of a player that just got home with one
+	closeLoop func(player *Player)
+}
+
+// stepPlayer moves the player one tick along the playfield, laying its tail,
+// cutting other tails and closing loops on the way. It reports whether the
+// player made its whole move, a slow player sitting out the tick, a death or
+// getting home all end it early.
+func stepPlayer(field *playfield, player *Player) bool {
+	if player.protectedTicks > 0 {
+		player.protectedTicks--
+	}
+
+	if player.Speed < 0 {
+		if player.ticksSkippedCount < player.Speed*-1 {
+			// we are skipping this tick because we are slow
+			player.ticksSkippedCount += 1
+			return false
+		}
+
+		player.ticksSkippedCount = 0
+	}
+
+	for _, nextTile := range player.nextTilesOn(field.gameMap) {
+		if isWallOn(field.gameMap, nextTile) {
+			player.isDead = true
+			player.deathEvent = &GameEvent{Kind: EventWall, ActorName: player.Name, ActorColor: *player.Color}
+			player.History.recordDeath(field.tick, nil, false)
+			field.endPlayer(player)
+			return false
+		}
+
+		player.isSafe = false
+
+		if nextTile.OwnerColor != nil && nextTile.OwnerColor != player.Color {
+			nextTileOwner := field.playerByColor(*nextTile.OwnerColor)
+			if nextTileOwner == nil {
+				continue
+			}
+
+			if nextTileOwner.isDead || nextTileOwner.isSafe {
+				continue
+			}
+
+			// head to head collision
+			if nextTileOwner.Location == nextTile {
+				// fresh spawns just bump heads
+				if player.IsProtected() || nextTileOwner.IsProtected() {
+					continue
+				}
+
+				nextTileOwner.isDead = true
+				player.isDead = true
+				player.Kills += 1
+				nextTileOwner.Kills += 1
+				player.History.recordDeath(field.tick, nextTileOwner, true)
+				nextTileOwner.History.recordDeath(field.tick, player, true)
+				// one of the two deaths is enough to report it
+				player.deathEvent = &GameEvent{
+					Kind:        EventHeadOn,
+					ActorName:   player.Name,
+					ActorColor:  *player.Color,
+					VictimName:  nextTileOwner.Name,
+					VictimColor: *nextTileOwner.Color,
+				}
+
+				field.endPlayer(nextTileOwner)
+				field.endPlayer(player)
+				return false
+			}
+
+			// I'm a killer, unless the tail is fresh from spawning, then we just run over it
+			if nextTile.IsTail && !nextTileOwner.IsProtected() {
+				nextTileOwner.isDead = true
+				nextTileOwner.deathEvent = &GameEvent{
+					Kind:        EventTailCut,
+					ActorName:   player.Name,
+					ActorColor:  *player.Color,
+					VictimName:  nextTileOwner.Name,
+					VictimColor: *nextTileOwner.Color,
+				}
+				nextTileOwner.History.recordDeath(field.tick, player, false)
+				field.endPlayer(nextTileOwner)
+
+				player.Kills += 1
+				player.History.recordKill(field.tick, nextTileOwner)
+				field.territory.setTileOwner(nextTile, player.Color, true)
+				player.Tail.tailLock.Lock()
+				player.Tail.tailTiles = append(player.Tail.tailTiles, nextTile)
+				player.Tail.tailLock.Unlock()
+
+				player.Location = nextTile
+				continue
+			}
+
+		}
+
+		if nextTile.OwnerColor == player.Color && len(player.Tail.tailTiles) > 0 {
+			field.closeLoop(player)
+
+			player.Location = nextTile
+			player.isSafe = true
+			return false
+		}
+
+		if nextTile.OwnerColor != player.Color {
+			previousOwner := nextTile.OwnerColor
+			wasLand := previousOwner != nil && !nextTile.IsTail
+			field.territory.setTileOwner(nextTile, player.Color, true)
+			nextTile.Direction = player.CurrentDirection
+			player.Tail.tailLock.Lock()
+			player.Tail.tailTiles = append(player.Tail.tailTiles, nextTile)
+			if wasLand {
+				if player.Tail.stolenFrom == nil {
+					player.Tail.stolenFrom = make(map[*Tile]int)
+				}
+				player.Tail.stolenFrom[nextTile] = *previousOwner
+			}
+			player.Tail.tailLock.Unlock()
+		}
+
+		player.Location = nextTile
+	}
+
+	return true
+}
+
+// isWallOn is IsWall for a tile of any map, where the arena doesn't wrap
+// around the map's edge is a wall too.
+func isWallOn(gameMap [][]*Tile, tile *Tile) bool {
+	if !WrapAround && (tile.Y <= 0 || tile.X <= 0 || tile.Y >= len(gameMap)-1 || tile.X >= len(gameMap[0])-1) {
+		return true
+	}
+	return tile.Terrain == TerrainWall
+}
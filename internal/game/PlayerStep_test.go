@@ -0,0 +1,293 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+// Step maps are drawn with:
+//
+//	.  free ground
+//	P  the player's land, the player starts on the first one heading right
+//	t  the player's tail
+//	r  the rival's land
+//	s  the rival's tail
+//	#  wall
+//
+// The edge of the drawing is the edge of the map, which is a wall as well.
+type stepWorld struct {
+	field  *playfield
+	player *Player
+	rival  *Player
+	ended  []*Player
+	claims int
+}
+
+func newStepWorld(t *testing.T, shape []string) *stepWorld {
+	t.Helper()
+	playerColor, rivalColor := testPlayerColor, testRivalColor
+	world := &stepWorld{
+		player: &Player{Name: "player", Color: &playerColor, CurrentDirection: Direction{Dx: 1}},
+		rival:  &Player{Name: "rival", Color: &rivalColor},
+	}
+	world.field = &playfield{
+		gameMap:   make([][]*Tile, len(shape)),
+		territory: &TerritoryCounter{},
+		playerByColor: func(color int) *Player {
+			for _, player := range []*Player{world.player, world.rival} {
+				if *player.Color == color {
+					return player
+				}
+			}
+			return nil
+		},
+		endPlayer: func(player *Player) { world.ended = append(world.ended, player) },
+		closeLoop: func(player *Player) {
+			claimTail(world.field.gameMap, world.field.territory, player)
+			player.resetTailData()
+			world.claims++
+		},
+	}
+
+	for row, line := range shape {
+		if len(line) != len(shape[0]) {
+			t.Fatalf("row %d is %d wide, want %d", row, len(line), len(shape[0]))
+		}
+		world.field.gameMap[row] = make([]*Tile, len(line))
+		for col, cell := range line {
+			tile := CreateNewTile(row, col)
+			switch cell {
+			case 'P':
+				world.field.territory.setTileOwner(tile, world.player.Color, false)
+				if world.player.Location == nil {
+					world.player.Location = tile
+				}
+			case 't':
+				world.field.territory.setTileOwner(tile, world.player.Color, true)
+				world.player.Tail.tailTiles = append(world.player.Tail.tailTiles, tile)
+			case 'r':
+				world.field.territory.setTileOwner(tile, world.rival.Color, false)
+			case 's':
+				world.field.territory.setTileOwner(tile, world.rival.Color, true)
+				world.rival.Tail.tailTiles = append(world.rival.Tail.tailTiles, tile)
+			case '#':
+				tile.Terrain = TerrainWall
+			}
+			world.field.gameMap[row][col] = tile
+		}
+	}
+	return world
+}
+
+func (w *stepWorld) tile(row int, col int) *Tile {
+	return w.field.gameMap[row][col]
+}
+
+// step heads the player in direction for a number of ticks, or until it
+// dies, and reports whether the last one was a whole move.
+func (w *stepWorld) step(direction Direction, ticks int) bool {
+	w.player.CurrentDirection = direction
+	moved := false
+	for range ticks {
+		if w.player.isDead {
+			break
+		}
+		moved = stepPlayer(w.field, w.player)
+	}
+	return moved
+}
+
+func (w *stepWorld) draw() []string {
+	shape := []string{}
+	for _, row := range w.field.gameMap {
+		line := []byte{}
+		for _, tile := range row {
+			cell := byte('.')
+			switch {
+			case tile.Terrain == TerrainWall:
+				cell = '#'
+			case tile.OwnerColor == w.player.Color && tile.IsTail:
+				cell = 't'
+			case tile.OwnerColor == w.player.Color:
+				cell = 'P'
+			case tile.OwnerColor == w.rival.Color && tile.IsTail:
+				cell = 's'
+			case tile.OwnerColor == w.rival.Color:
+				cell = 'r'
+			}
+			line = append(line, cell)
+		}
+		shape = append(shape, string(line))
+	}
+	return shape
+}
+
+func (w *stepWorld) checkDrawing(t *testing.T, want []string) {
+	t.Helper()
+	if got := w.draw(); !slices.Equal(got, want) {
+		t.Errorf("map is\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestStepPlayerClosesALoop(t *testing.T) {
+	world := newStepWorld(t, []string{
+		".........",
+		".PP......",
+		".PP......",
+		".........",
+		".........",
+		".........",
+	})
+	world.player.Location = world.tile(1, 2)
+
+	world.step(Direction{Dx: 1}, 3)
+	world.step(Direction{Dy: 1}, 3)
+	world.step(Direction{Dx: -1}, 4)
+	world.checkDrawing(t, []string{
+		".........",
+		".PPttt...",
+		".PP..t...",
+		".....t...",
+		".ttttt...",
+		".........",
+	})
+
+	if moved := world.step(Direction{Dy: -1}, 2); moved {
+		t.Error("getting home was reported as a whole move")
+	}
+	world.checkDrawing(t, []string{
+		".........",
+		".PPPPP...",
+		".PPPPP...",
+		".PPPPP...",
+		".PPPPP...",
+		".........",
+	})
+	if world.claims != 1 || !world.player.isSafe || len(world.player.Tail.tailTiles) != 0 {
+		t.Errorf("claims %d, safe %v, tail %d, want one claim, safe and no tail",
+			world.claims, world.player.isSafe, len(world.player.Tail.tailTiles))
+	}
+	if land := world.field.territory.Get(testPlayerColor); land != 20 {
+		t.Errorf("land counted %d, want 20", land)
+	}
+}
+
+func TestStepPlayerHitsAWall(t *testing.T) {
+	for _, shape := range [][]string{
+		{
+			".....",
+			".P...",
+			".....",
+		},
+		{
+			".......",
+			".P.#...",
+			".......",
+		},
+	} {
+		world := newStepWorld(t, shape)
+		world.step(Direction{Dx: 1}, 3)
+
+		if !slices.Equal(world.ended, []*Player{world.player}) || !world.player.isDead {
+			t.Fatalf("%v: ended %d players, dead %v, want the player dead", shape, len(world.ended), world.player.isDead)
+		}
+		if world.player.deathEvent == nil || world.player.deathEvent.Kind != EventWall {
+			t.Errorf("%v: death event %+v, want a wall", shape, world.player.deathEvent)
+		}
+	}
+}
+
+func TestStepPlayerMeetsARival(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		shape          []string
+		rivalHead      [2]int
+		rivalProtected bool
+		wantEnded      []string
+		wantKills      int
+		want           []string
+	}{
+		{
+			name:      "cuts the rival's tail",
+			shape:     []string{".......", ".Pss...", "......."},
+			rivalHead: [2]int{1, 3},
+			wantEnded: []string{"rival"},
+			wantKills: 1,
+			want:      []string{".......", ".Pts...", "......."},
+		},
+		{
+			name:           "runs over a tail fresh from spawning",
+			shape:          []string{".......", ".Pss...", "......."},
+			rivalHead:      [2]int{1, 3},
+			rivalProtected: true,
+			want:           []string{".......", ".Pts...", "......."},
+		},
+		{
+			name:      "meets the rival head on",
+			shape:     []string{".......", ".Ps....", "......."},
+			rivalHead: [2]int{1, 2},
+			wantEnded: []string{"rival", "player"},
+			wantKills: 1,
+			want:      []string{".......", ".Ps....", "......."},
+		},
+		{
+			name:           "bumps heads with a fresh spawn",
+			shape:          []string{".......", ".Ps....", "......."},
+			rivalHead:      [2]int{1, 2},
+			rivalProtected: true,
+			want:           []string{".......", ".Ps....", "......."},
+		},
+		{
+			name:      "lays the tail over the rival's land",
+			shape:     []string{".......", ".Pr..r.", "......."},
+			rivalHead: [2]int{1, 5},
+			want:      []string{".......", ".Pt..r.", "......."},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			world := newStepWorld(t, tc.shape)
+			world.rival.Location = world.tile(tc.rivalHead[0], tc.rivalHead[1])
+			if tc.rivalProtected {
+				world.rival.protectedTicks = 5
+			}
+
+			world.step(Direction{Dx: 1}, 1)
+
+			ended := []string{}
+			for _, player := range world.ended {
+				ended = append(ended, player.Name)
+			}
+			if !slices.Equal(ended, tc.wantEnded) {
+				t.Errorf("ended %v, want %v", ended, tc.wantEnded)
+			}
+			if world.player.Kills != tc.wantKills {
+				t.Errorf("player has %d kills, want %d", world.player.Kills, tc.wantKills)
+			}
+			world.checkDrawing(t, tc.want)
+		})
+	}
+}
+
+func TestStepPlayerSpeed(t *testing.T) {
+	world := newStepWorld(t, []string{
+		"..........",
+		".P........",
+		"..........",
+	})
+
+	world.player.Speed = 2
+	world.step(Direction{Dx: 1}, 1)
+	world.checkDrawing(t, []string{
+		"..........",
+		".Ptt......",
+		"..........",
+	})
+
+	world.player.Speed = -1
+	if moved := world.step(Direction{Dx: 1}, 1); moved || world.player.Location != world.tile(1, 3) {
+		t.Errorf("a slow player moved on its skipped tick, it is at %d,%d", world.player.Location.Y, world.player.Location.X)
+	}
+	if moved := world.step(Direction{Dx: 1}, 1); !moved || world.player.Location != world.tile(1, 4) {
+		t.Errorf("a slow player didn't move after skipping a tick, it is at %d,%d", world.player.Location.Y, world.player.Location.X)
+	}
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// PopulationController keeps the total number of snakes near targetPopulation.
+// Bots are benched a few at a time as humans join and brought back when the
+// server empties, without ever touching a bot that is out on a loop.
+type PopulationController struct {
+	GameManager *GameManager
+}
+
+type populationCensus struct {
+	humans          int
+	bots            []*Player
+	humansPerRegion map[int]int
+	botsPerRegion   map[int]int
+}
+
+func NewPopulationController(gameManager *GameManager) *PopulationController {
+	return &PopulationController{GameManager: gameManager}
+}
+
+func (pc *PopulationController) Run(ctx context.Context) {
+	ticker := time.NewTicker(populationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pc.rebalance()
+		}
+	}
+}
+
+func populationRegionOf(tile *Tile) int {
+	regionsPerRow := (MapColCount + populationRegionSize - 1) / populationRegionSize
+	return (tile.Y/populationRegionSize)*regionsPerRow + tile.X/populationRegionSize
+}
+
+func (pc *PopulationController) takeCensus() populationCensus {
+	census := populationCensus{
+		humansPerRegion: make(map[int]int),
+		botsPerRegion:   make(map[int]int),
+	}
+
+	pc.GameManager.Players.Range(func(_, value interface{}) bool {
+		player, ok := value.(*Player)
+		if !ok || player == nil || player.isDead {
+			return true
+		}
+
+		region := populationRegionOf(player.Location)
+		if player.BotStrategy == nil {
+			census.humans++
+			census.humansPerRegion[region]++
+		} else {
+			census.bots = append(census.bots, player)
+			census.botsPerRegion[region]++
+		}
+		return true
+	})
+
+	return census
+}
+
+func desiredBotCount(humans int) int {
+	return max(minBotCount, targetPopulation-humans)
+}
+
+// shouldRespawn tells the rebirth worker whether a dead bot is still needed.
+func (pc *PopulationController) shouldRespawn() bool {
+	census := pc.takeCensus()
+	pending := pc.GameManager.PlayerManager.countSlots(botSlotRespawning)
+
+	return len(census.bots)+pending < desiredBotCount(census.humans)
+}
+
+func (pc *PopulationController) rebalance() {
+	census := pc.takeCensus()
+	pending := pc.GameManager.PlayerManager.countSlots(botSlotRespawning)
+	activeBots := len(census.bots) + pending
+	desired := desiredBotCount(census.humans)
+
+	switch {
+	case activeBots < desired:
+		pc.GameManager.PlayerManager.unparkBots(min(populationStep, desired-activeBots))
+	case activeBots > desired:
+		pc.retireBots(census.bots, census, min(populationStep, activeBots-desired))
+	}
+
+	// regions where humans are outnumbered get thinned out even when the
+	// total is fine, the benched bots come back somewhere emptier
+	for region, humans := range census.humansPerRegion {
+		overflow := census.botsPerRegion[region] - humans*maxBotsPerHumanInRegion
+		if overflow <= 0 {
+			continue
+		}
+
+		regionBots := []*Player{}
+		for _, bot := range census.bots {
+			if !bot.isDead && populationRegionOf(bot.Location) == region {
+				regionBots = append(regionBots, bot)
+			}
+		}
+		pc.retireBots(regionBots, census, min(populationStep, overflow))
+	}
+}
+
+// retireBots benches up to count idle bots, most crowded regions first.
+func (pc *PopulationController) retireBots(candidates []*Player, census populationCensus, count int) {
+	idleBots := []*Player{}
+	for _, bot := range candidates {
+		if pc.isIdle(bot) {
+			idleBots = append(idleBots, bot)
+		}
+	}
+
+	crowding := func(bot *Player) int {
+		region := populationRegionOf(bot.Location)
+		return census.botsPerRegion[region] / (census.humansPerRegion[region] + 1)
+	}
+	sort.Slice(idleBots, func(i, j int) bool {
+		return crowding(idleBots[i]) > crowding(idleBots[j])
+	})
+
+	for _, bot := range idleBots[:min(count, len(idleBots))] {
+		census.botsPerRegion[populationRegionOf(bot.Location)]--
+		pc.GameManager.PlayerManager.parkBot(bot)
+	}
+}
+
+// isIdle is true for bots that are sitting on their own land with no loop out.
+func (pc *PopulationController) isIdle(bot *Player) bool {
+	if bot.isDead {
+		return false
+	}
+
+	bot.Tail.tailLock.Lock()
+	defer bot.Tail.tailLock.Unlock()
+	return len(bot.Tail.tailTiles) <= 1
+}
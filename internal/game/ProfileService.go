@@ -0,0 +1,114 @@
+package game
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// ProfileService keeps per player settings next to the high scores. Players
+// have no accounts, so a profile belongs to whoever plays under that name.
+// The server opens one for all sessions, a nil one answers every call with
+// errProfilesUnavailable so the game goes on without profiles.
+type ProfileService struct {
+	db *sql.DB
+}
+
+const profilesTableName = "profiles"
+
+var errProfilesUnavailable = errors.New("profiles are unavailable")
+
+type Profile struct {
+	PlayerName     string
+	ColorblindMode string
+	Keymap         string
+}
+
+func NewProfileService() (*ProfileService, error) {
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	service := &ProfileService{db: db}
+	if err := service.createTable(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := service.migrateTable(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate profiles table: %w", err)
+	}
+
+	return service, nil
+}
+
+func (serviceImpl *ProfileService) Close() error {
+	if serviceImpl == nil {
+		return nil
+	}
+	return serviceImpl.db.Close()
+}
+
+func (serviceImpl *ProfileService) createTable() error {
+	const createTableSQL = `
+	CREATE TABLE IF NOT EXISTS ` + profilesTableName + ` (
+		player_name TEXT PRIMARY KEY,
+		colorblind_mode TEXT NOT NULL DEFAULT '',
+		keymap TEXT NOT NULL DEFAULT '',
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	_, err := serviceImpl.db.Exec(createTableSQL)
+	if err != nil {
+		return fmt.Errorf("failed to execute CREATE TABLE: %w", err)
+	}
+	return nil
+}
+
+// migrateTable adds columns introduced after the table was first created.
+func (serviceImpl *ProfileService) migrateTable() error {
+	return ensureColumn(serviceImpl.db, profilesTableName, "keymap", "TEXT NOT NULL DEFAULT ''")
+}
+
+// GetProfile returns the saved profile, or an empty one for a new name.
+func (serviceImpl *ProfileService) GetProfile(playerName string) (Profile, bool, error) {
+	const selectSQL = `
+	SELECT player_name, colorblind_mode, keymap
+	FROM ` + profilesTableName + `
+	WHERE player_name = ?;`
+
+	profile := Profile{PlayerName: playerName}
+	if serviceImpl == nil {
+		return profile, false, errProfilesUnavailable
+	}
+	err := serviceImpl.db.QueryRow(selectSQL, playerName).Scan(&profile.PlayerName, &profile.ColorblindMode, &profile.Keymap)
+	if errors.Is(err, sql.ErrNoRows) {
+		return profile, false, nil
+	}
+	if err != nil {
+		return profile, false, fmt.Errorf("failed to load profile for %s: %w", playerName, err)
+	}
+	return profile, true, nil
+}
+
+func (serviceImpl *ProfileService) SaveProfile(profile Profile) error {
+	const upsertSQL = `
+	INSERT INTO ` + profilesTableName + ` (player_name, colorblind_mode, keymap)
+	VALUES (?, ?, ?)
+	ON CONFLICT(player_name) DO UPDATE SET
+		colorblind_mode = excluded.colorblind_mode,
+		keymap = excluded.keymap,
+		updated_at = CURRENT_TIMESTAMP;`
+
+	if serviceImpl == nil {
+		return errProfilesUnavailable
+	}
+	_, err := serviceImpl.db.Exec(upsertSQL, profile.PlayerName, profile.ColorblindMode, profile.Keymap)
+	if err != nil {
+		return fmt.Errorf("failed to save profile for %s: %w", profile.PlayerName, err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package game
+
+// RadarContact is a rival a human player should know about even when it is
+// off screen: a head closing in on their tail, or a tail they could cut.
+type RadarContact struct {
+	Color    int
+	X        int
+	Y        int
+	Distance int
+	Threat   bool
+}
+
+// radarContacts sees threats the way the bots do, rival heads near the tail,
+// and targets as the closest tail tile of every rival in reach of the head.
+func (gm *GameManager) radarContacts(player *Player) []RadarContact {
+	contacts := []RadarContact{}
+
+	for opponent, dist := range tailThreats(player, gm, radarRadius) {
+		contacts = append(contacts, RadarContact{
+			Color:    *opponent.Color,
+			X:        opponent.Location.X,
+			Y:        opponent.Location.Y,
+			Distance: dist,
+			Threat:   true,
+		})
+	}
+
+	nearestTails := map[int]RadarContact{}
+	for _, tailTile := range gm.SpatialIndex.TailsWithin(player.Location, radarRadius, *player.Color) {
+		ownerColor := tailTile.OwnerColor
+		if ownerColor == nil {
+			continue
+		}
+		// spawn protected tails can't be cut yet
+		if owner, ok := gm.Players.Load(*ownerColor); !ok || owner.(*Player).IsProtected() {
+			continue
+		}
+
+		dist := GetManhattanDistance(player.Location, tailTile)
+		if known, ok := nearestTails[*ownerColor]; !ok || dist < known.Distance {
+			nearestTails[*ownerColor] = RadarContact{Color: *ownerColor, X: tailTile.X, Y: tailTile.Y, Distance: dist}
+		}
+	}
+	for _, contact := range nearestTails {
+		contacts = append(contacts, contact)
+	}
+
+	return contacts
+}
@@ -0,0 +1,227 @@
+package game
+
+// Sandbox is a small private world for the tutorial: one player and a dummy
+// snake circling next to its home. It has its own map and land counter and
+// shares none of the game's workers, chat or high scores, so nothing done in
+// it counts anywhere, and it moves one tick whenever its owner calls Step.
+// The player moves and claims by the game's own stepPlayer and claimTail.
+type Sandbox struct {
+	GameMap   [][]*Tile
+	Player    *Player
+	Dummy     *Player
+	DummyDead bool
+
+	territory    *TerritoryCounter
+	field        *playfield
+	home         *Tile
+	dummyHome    *Tile
+	dummyStep    int
+	dummyRespawn uint64
+	tick         uint64
+	turns        int
+	claims       int
+	slowDowns    int
+	deaths       int
+}
+
+// SandboxProgress is what the player did in the sandbox so far, the tutorial
+// reads its steps off it.
+type SandboxProgress struct {
+	Turns      int
+	TailLength int
+	Speed      int
+	Claims     int
+	Kills      int
+	SlowDowns  int
+	Deaths     int
+}
+
+// sandboxDummyLoop is the dummy's walk out of its home and back, one
+// direction per move.
+var sandboxDummyLoop = func() []Direction {
+	loop := []Direction{}
+	for _, leg := range []struct {
+		direction Direction
+		moves     int
+	}{{Direction{Dx: -1}, 12}, {Direction{Dy: 1}, 4}, {Direction{Dx: 1}, 12}, {Direction{Dy: -1}, 4}} {
+		for range leg.moves {
+			loop = append(loop, leg.direction)
+		}
+	}
+	return loop
+}()
+
+func NewSandbox() *Sandbox {
+	sandbox := &Sandbox{GameMap: make([][]*Tile, SandboxRows), territory: &TerritoryCounter{}}
+	for row := range SandboxRows {
+		sandbox.GameMap[row] = make([]*Tile, SandboxCols)
+		for col := range SandboxCols {
+			tile := CreateNewTile(row, col)
+			if row == 0 || col == 0 || row == SandboxRows-1 || col == SandboxCols-1 {
+				tile.Terrain = TerrainWall
+			}
+			sandbox.GameMap[row][col] = tile
+		}
+	}
+
+	sandbox.home = sandbox.GameMap[SandboxRows/2][8]
+	sandbox.dummyHome = sandbox.GameMap[SandboxRows/2][SandboxCols-12]
+	sandbox.Player = sandbox.spawn("You", SandboxPlayerColor, sandbox.home, Direction{Dx: 1})
+	sandbox.Dummy = sandbox.spawn("Dummy", SandboxDummyColor, sandbox.dummyHome, sandboxDummyLoop[0])
+	sandbox.field = &playfield{
+		gameMap:       sandbox.GameMap,
+		territory:     sandbox.territory,
+		playerByColor: sandbox.playerByColor,
+		endPlayer:     sandbox.endPlayer,
+		closeLoop:     sandbox.closeLoop,
+	}
+
+	return sandbox
+}
+
+func (s *Sandbox) spawn(name string, color int, home *Tile, direction Direction) *Player {
+	player := &Player{Name: name, Color: &color, Location: home, CurrentDirection: direction}
+	for row := home.Y - spawnSquareRadius; row <= home.Y+spawnSquareRadius; row++ {
+		for col := home.X - spawnSquareRadius; col <= home.X+spawnSquareRadius; col++ {
+			tile := s.GameMap[row][col]
+			s.territory.setTileOwner(tile, player.Color, false)
+			player.AllTiles.AllPlayerTiles = append(player.AllTiles.AllPlayerTiles, tile)
+		}
+	}
+	return player
+}
+
+func (s *Sandbox) playerByColor(color int) *Player {
+	switch color {
+	case *s.Player.Color:
+		return s.Player
+	case *s.Dummy.Color:
+		return s.Dummy
+	}
+	return nil
+}
+
+// Press steers the player the way the game's direction keys do, the way it
+// is already going speeds it up and the opposite way slows it down.
+func (s *Sandbox) Press(direction Direction) {
+	player := s.Player
+	switch {
+	case direction.Dx == player.CurrentDirection.Dx && direction.Dy == player.CurrentDirection.Dy:
+		player.Speed++
+	case direction.Dx == -player.CurrentDirection.Dx && direction.Dy == -player.CurrentDirection.Dy:
+		player.Speed--
+		s.slowDowns++
+	default:
+		player.UpdateDirection(Direction{Dx: direction.Dx, Dy: direction.Dy})
+		s.turns++
+	}
+}
+
+func (s *Sandbox) Brake() {
+	if s.Player.Speed > 0 {
+		s.slowDowns++
+	}
+	s.Player.ResetSpeed()
+}
+
+func (s *Sandbox) Progress() SandboxProgress {
+	return SandboxProgress{
+		Turns:      s.turns,
+		TailLength: len(s.Player.Tail.tailTiles),
+		Speed:      s.Player.Speed,
+		Claims:     s.claims,
+		Kills:      s.Player.Kills,
+		SlowDowns:  s.slowDowns,
+		Deaths:     s.deaths,
+	}
+}
+
+func (s *Sandbox) Land(color int) int {
+	return s.territory.Get(color)
+}
+
+// MapCopy is the whole sandbox, the way GetMapCopy returns a window of the game.
+func (s *Sandbox) MapCopy() [][]Tile {
+	mapCopy := make([][]Tile, SandboxRows)
+	for row := range SandboxRows {
+		mapCopy[row] = make([]Tile, SandboxCols)
+		for col := range SandboxCols {
+			mapCopy[row][col] = *s.GameMap[row][col]
+		}
+	}
+	return mapCopy
+}
+
+// Step moves everybody one tick along, the player by the game's rules. Dying
+// here only sends the player home without its tail.
+func (s *Sandbox) Step() {
+	s.tick++
+	s.field.tick = s.tick
+	stepPlayer(s.field, s.Player)
+
+	switch {
+	case s.DummyDead && s.tick >= s.dummyRespawn:
+		s.DummyDead, s.Dummy.isDead = false, false
+		s.Dummy.Location, s.dummyStep = s.dummyHome, 0
+	case !s.DummyDead && s.tick%sandboxDummyMoveTicks == 0:
+		s.moveDummy()
+	}
+}
+
+// endPlayer puts the player back home, and takes the dummy off the map for a
+// while.
+func (s *Sandbox) endPlayer(player *Player) {
+	if player == s.Dummy {
+		s.killDummy()
+		return
+	}
+
+	s.clearTail(player)
+	player.isDead, player.deathEvent = false, nil
+	player.Location = s.home
+	player.CurrentDirection = Direction{Dx: 1}
+	player.ResetSpeed()
+	s.deaths++
+}
+
+func (s *Sandbox) closeLoop(player *Player) {
+	claimTail(s.GameMap, s.territory, player)
+	player.resetTailData()
+	s.claims++
+}
+
+// moveDummy walks the dummy around its loop. It never keeps land, the tail
+// fades once it is home so there is always a fresh one to cut, and it steps
+// over the player's tiles without taking them.
+func (s *Sandbox) moveDummy() {
+	dummy := s.Dummy
+	dummy.CurrentDirection = sandboxDummyLoop[s.dummyStep]
+	nextTile := s.GameMap[dummy.Location.Y+dummy.CurrentDirection.Dy][dummy.Location.X+dummy.CurrentDirection.Dx]
+
+	if nextTile.OwnerColor == nil {
+		s.territory.setTileOwner(nextTile, dummy.Color, true)
+		dummy.Tail.tailTiles = append(dummy.Tail.tailTiles, nextTile)
+	}
+	dummy.Location = nextTile
+
+	s.dummyStep = (s.dummyStep + 1) % len(sandboxDummyLoop)
+	if s.dummyStep == 0 {
+		s.clearTail(dummy)
+	}
+}
+
+func (s *Sandbox) killDummy() {
+	s.clearTail(s.Dummy)
+	s.DummyDead = true
+	s.dummyRespawn = s.tick + sandboxDummyRespawnTicks
+}
+
+// clearTail gives back the tail tiles the player still holds.
+func (s *Sandbox) clearTail(player *Player) {
+	for _, tile := range player.Tail.tailTiles {
+		if tile.OwnerColor == player.Color && tile.IsTail {
+			s.territory.setTileOwner(tile, nil, false)
+		}
+	}
+	player.resetTailData()
+}
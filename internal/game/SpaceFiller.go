@@ -1,27 +1,30 @@
 package game
 
 import (
+	"log"
+	"slices"
 	"sync"
-	"sync/atomic"
 )
 
 type SpaceFiller struct {
 	SpaceFillerChan chan *Player
 	GameMap         [][]*Tile
 	SpaceFillerWg   *sync.WaitGroup
+	GameManager     *GameManager
 }
 
 var spaceFiller *SpaceFiller
 
-func getNewSpaceFiller(gameMap [][]*Tile) *SpaceFiller {
+func getNewSpaceFiller(gameManager *GameManager) *SpaceFiller {
 	if spaceFiller != nil {
 		return spaceFiller
 	}
 
 	spaceFiller := SpaceFiller{
 		SpaceFillerChan: make(chan *Player),
-		GameMap:         gameMap,
+		GameMap:         gameManager.GameMap,
 		SpaceFillerWg:   &sync.WaitGroup{},
+		GameManager:     gameManager,
 	}
 
 	for w := 0; w < spaceFillerChannelWorkers; w++ {
@@ -44,123 +47,315 @@ func (spaceFillerInstance *SpaceFiller) spaceFillWorker() {
 
 		if player != nil && len(player.Tail.tailTiles) > 0 {
 			spaceFillerInstance.SpaceFillerWg.Add(1)
-			spaceFillerInstance.spaceFillFromTail(player)
+			stolenTiles, claimedTiles := spaceFillerInstance.spaceFillFromTail(player)
 			player.resetTailData()
+			spaceFillerInstance.reportTheft(player, stolenTiles)
+			player.History.recordClaim(spaceFillerInstance.GameManager.GetTickSnapshot().Tick, claimedTiles)
+
+			if claimedTiles >= eventClaimThreshold {
+				spaceFillerInstance.GameManager.Events.publish(GameEvent{
+					Kind:       EventClaim,
+					ActorName:  player.Name,
+					ActorColor: *player.Color,
+					Tiles:      claimedTiles,
+				})
+			}
 		}
 	}
 }
 
-func (sf *SpaceFiller) spaceFillFromTail(player *Player) {
-	spaceFilled := false
+func (sf *SpaceFiller) spaceFillFromTail(player *Player) (map[int]int, int) {
 	defer sf.SpaceFillerWg.Done()
+	return claimTail(sf.GameMap, sf.GameManager.Territory, player)
+}
+
+// claimTail turns the tail into land, claims whatever it enclosed and returns
+// how many tiles were taken from each other color and claimed in total. The
+// tail is left for the caller to reset.
+func claimTail(gameMap [][]*Tile, territory *TerritoryCounter, player *Player) (map[int]int, int) {
 	player.Tail.tailLock.Lock()
 	defer player.Tail.tailLock.Unlock()
+	player.AllTiles.allTilesLock.Lock()
+	defer player.AllTiles.allTilesLock.Unlock()
+
+	bounds := newTileBounds()
+	for _, segment := range player.Tail.tailTiles {
+		if segment.OwnerColor == player.Color {
+			bounds.include(segment)
+		}
+	}
+	for _, tile := range player.AllTiles.AllPlayerTiles {
+		if tile.OwnerColor == player.Color {
+			bounds.include(tile)
+		}
+	}
 
-	for i := (len(player.Tail.tailTiles) - 1); i >= 0; i-- {
-		segment := player.Tail.tailTiles[i]
-		segmentRow, segmentCol := segment.Y, segment.X
-
-		topTile, bottomTile, leftTile, rightTile := sf.GameMap[segmentRow-1][segmentCol],
-			sf.GameMap[segmentRow+1][segmentCol],
-			sf.GameMap[segmentRow][segmentCol-1],
-			sf.GameMap[segmentRow][segmentCol+1]
-
-		if !spaceFilled && player.Location != segment {
-			if topTile.OwnerColor != segment.OwnerColor &&
-				bottomTile.OwnerColor != segment.OwnerColor &&
-				leftTile.OwnerColor == segment.OwnerColor &&
-				rightTile.OwnerColor == segment.OwnerColor {
-				spaceFilled = true
-				sf.fillWithSeeds(player, topTile, bottomTile)
-			} else if leftTile.OwnerColor != segment.OwnerColor &&
-				rightTile.OwnerColor != segment.OwnerColor &&
-				bottomTile.OwnerColor == segment.OwnerColor &&
-				topTile.OwnerColor == segment.OwnerColor {
-				spaceFilled = true
-				sf.fillWithSeeds(player, leftTile, rightTile)
+	stolenTiles := make(map[int]int)
+	claimedTiles := 0
+	for _, tile := range findEnclosedTiles(gameMap, player.Color, bounds) {
+		claimedTiles++
+		// a rival's tail caught in the loop was never their land
+		if tile.OwnerColor != nil && !tile.IsTail {
+			stolenTiles[*tile.OwnerColor]++
+		}
+		territory.setTileOwner(tile, player.Color, false)
+		player.AllTiles.AllPlayerTiles = append(player.AllTiles.AllPlayerTiles, tile)
+	}
+
+	for _, segment := range player.Tail.tailTiles {
+		if segment.OwnerColor != player.Color {
+			continue
+		}
+		// tails may cross no-build ground but it never becomes land
+		if !segment.IsBuildable() {
+			territory.setTileOwner(segment, nil, false)
+			continue
+		}
+		if segment.IsTail {
+			territory.setTileOwner(segment, player.Color, false)
+			claimedTiles++
+			if victimColor, ok := player.Tail.stolenFrom[segment]; ok {
+				stolenTiles[victimColor]++
 			}
 		}
-		player.AllTiles.allTilesLock.Lock()
 		player.AllTiles.AllPlayerTiles = append(player.AllTiles.AllPlayerTiles, segment)
-		player.AllTiles.allTilesLock.Unlock()
-		segment.IsTail = false
 	}
+
+	// keep the list from filling up with tiles other players took back
+	if len(player.AllTiles.AllPlayerTiles) > 2*territory.Get(*player.Color)+consolidateSlack {
+		player.consolidateTilesLocked()
+	}
+
+	return stolenTiles, claimedTiles
+}
+
+// reportTheft settles the victims' books and tells them who robbed them. It runs
+// after the thief's locks are released so two players robbing each other
+// can't deadlock.
+func (sf *SpaceFiller) reportTheft(thief *Player, stolenTiles map[int]int) {
+	for victimColor, tilesCount := range stolenTiles {
+		thief.TilesStolen += tilesCount
+
+		victim := sf.GameManager.getPlayerByColor(victimColor)
+		if victim == nil || victim == thief {
+			continue
+		}
+
+		victim.ClaimedEstate = sf.GameManager.Territory.Get(victimColor)
+
+		if victim.SshSession == nil || victim.isDead {
+			continue
+		}
+
+		select {
+		case victim.UpdateChannel <- TerritoryStolenMsg{
+			ThiefName:  thief.Name,
+			ThiefColor: *thief.Color,
+			Tiles:      tilesCount,
+		}:
+		default:
+			log.Printf("Player %s update channel full, dropping theft notification", victim.Name)
+		}
+	}
+}
+
+type tileBounds struct {
+	minRow, maxRow, minCol, maxCol int
+	// the rows and columns in use, kept in wrap-around mode where the land
+	// may go across the seam
+	rows, cols []bool
 }
 
-func (sf *SpaceFiller) fillWithSeeds(player *Player, seedA *Tile, seedB *Tile) {
-	areaFound := &atomic.Bool{}
-	areaFound.Store(false)
-	wg := &sync.WaitGroup{}
-	if !IsWall(seedA.Y, seedA.X) {
-		wg.Add(1)
-		go sf.findAndFillTiles(player, seedA, wg, areaFound)
+func newTileBounds() tileBounds {
+	bounds := tileBounds{minRow: MapRowCount, maxRow: -1, minCol: MapColCount, maxCol: -1}
+	if WrapAround {
+		bounds.rows, bounds.cols = make([]bool, MapRowCount), make([]bool, MapColCount)
 	}
+	return bounds
+}
 
-	if !IsWall(seedB.Y, seedB.X) {
-		wg.Add(1)
-		go sf.findAndFillTiles(player, seedB, wg, areaFound)
+func (b *tileBounds) include(tile *Tile) {
+	b.minRow = min(b.minRow, tile.Y)
+	b.maxRow = max(b.maxRow, tile.Y)
+	b.minCol = min(b.minCol, tile.X)
+	b.maxCol = max(b.maxCol, tile.X)
+	if b.rows != nil && tile.Y < len(b.rows) && tile.X < len(b.cols) {
+		b.rows[tile.Y], b.cols[tile.X] = true, true
 	}
+}
 
-	wg.Wait()
+func (b *tileBounds) isEmpty() bool {
+	return b.maxRow < b.minRow || b.maxCol < b.minCol
 }
 
-func (sf *SpaceFiller) findAndFillTiles(
-	player *Player,
-	seed *Tile,
-	wg *sync.WaitGroup,
-	tilesFound *atomic.Bool,
-) {
-	defer wg.Done()
-	if tilesFound.Load() {
-		return
+// wrappedSpan finds the shortest stretch of lines around the seam that holds
+// every used one, with a free line on either end as the frame. It fails when
+// the land leaves fewer than two lines free, all the way around the torus.
+func wrappedSpan(used []bool) (int, int, bool) {
+	lines := len(used)
+	firstUsed := slices.Index(used, true)
+	if firstUsed < 0 {
+		return 0, 0, false
 	}
-	q := []*Tile{seed}
-	mapOfTilesToIgnore := make(map[*Tile]interface{})
 
-	for len(q) > 0 {
-		if tilesFound.Load() {
+	gapStart, gapLength := 0, 0
+	runStart, runLength := 0, 0
+	for offset := 1; offset <= lines; offset++ {
+		line := (firstUsed + offset) % lines
+		if used[line] {
+			runLength = 0
+			continue
+		}
+		if runLength == 0 {
+			runStart = line
+		}
+		runLength++
+		if runLength > gapLength {
+			gapStart, gapLength = runStart, runLength
+		}
+	}
+	if gapLength < 2 {
+		return 0, 0, false
+	}
+
+	return (gapStart + gapLength - 1) % lines, lines - gapLength + 2, true
+}
+
+// findEnclosedTiles floods the outside of everything owned by color, starting
+// from a frame one tile around its bounding box. Tiles the flood can't reach
+// are walled in by the player's land and tail, whatever the loop looks like.
+// Walls aren't the player's, so the flood runs through them like open ground,
+// and only the map's own terrain keeps them from being claimed, so any map
+// can be filled, not just the game's.
+func findEnclosedTiles(gameMap [][]*Tile, color *int, bounds tileBounds) []*Tile {
+	if bounds.isEmpty() {
+		return nil
+	}
+
+	minRow, maxRow := max(0, bounds.minRow-1), min(len(gameMap)-1, bounds.maxRow+1)
+	minCol, maxCol := max(0, bounds.minCol-1), min(len(gameMap[0])-1, bounds.maxCol+1)
+	height, width := maxRow-minRow+1, maxCol-minCol+1
+
+	// land touching the seam gets its box measured the short way around
+	if WrapAround && bounds.rows != nil {
+		spanned := true
+		if bounds.minRow < 1 || bounds.maxRow >= len(gameMap)-1 {
+			minRow, height, spanned = wrappedSpan(bounds.rows)
+		}
+		if spanned && (bounds.minCol < 1 || bounds.maxCol >= len(gameMap[0])-1) {
+			minCol, width, spanned = wrappedSpan(bounds.cols)
+		}
+		if !spanned {
+			return findEnclosedTilesWrapped(gameMap, color)
+		}
+	}
+
+	// in wrap-around mode the box may run over the seam, so every lookup
+	// folds back onto the map
+	mapRows, mapCols := len(gameMap), len(gameMap[0])
+	tileIn := func(row, col int) *Tile {
+		return gameMap[(minRow+row)%mapRows][(minCol+col)%mapCols]
+	}
+
+	outside := make([]bool, width*height)
+	q := []int{}
+
+	visit := func(row, col int) {
+		idx := row*width + col
+		if outside[idx] || tileIn(row, col).OwnerColor == color {
 			return
 		}
+		outside[idx] = true
+		q = append(q, idx)
+	}
 
-		testCoord := q[0]
-		q = q[1:]
+	// the frame never holds the player's land (or is the map's edge), so
+	// every free tile on it is outside
+	for col := range width {
+		visit(0, col)
+		visit(height-1, col)
+	}
+	for row := range height {
+		visit(row, 0)
+		visit(row, width-1)
+	}
 
-		testTile := sf.GameMap[testCoord.Y][testCoord.X]
-		mapOfTilesToIgnore[testTile] = true
+	for len(q) > 0 {
+		idx := q[0]
+		q = q[1:]
+		row, col := idx/width, idx%width
 
 		for _, dir := range Directions {
-			if tilesFound.Load() {
-				return
+			nextRow, nextCol := row+dir[0], col+dir[1]
+			if nextRow < 0 || nextRow >= height || nextCol < 0 || nextCol >= width {
+				continue
 			}
+			visit(nextRow, nextCol)
+		}
+	}
 
-			testRow, testCol := testTile.Y+dir[0], testTile.X+dir[1]
-			if IsWall(testRow, testCol) {
-				return
+	enclosed := []*Tile{}
+	for row := 1; row < height-1; row++ {
+		for col := 1; col < width-1; col++ {
+			tile := tileIn(row, col)
+			if !outside[row*width+col] && tile.OwnerColor != color && tile.IsBuildable() {
+				enclosed = append(enclosed, tile)
 			}
+		}
+	}
 
-			nextTile := sf.GameMap[testRow][testCol]
-			if _, ok := mapOfTilesToIgnore[nextTile]; ok {
-				continue
-			}
+	return enclosed
+}
 
-			if nextTile.OwnerColor == player.Color {
-				continue
-			}
+// findEnclosedTilesWrapped splits the whole wrapped map into areas the player's
+// land separates, for land that goes all the way around the torus and leaves
+// no frame to flood from. The biggest area is taken to be the outside and
+// everything else is enclosed.
+func findEnclosedTilesWrapped(gameMap [][]*Tile, color *int) []*Tile {
+	rows, cols := len(gameMap), len(gameMap[0])
+	component := make([]int, rows*cols)
+	componentSizes := []int{0}
 
-			q = append(q, nextTile)
-			mapOfTilesToIgnore[nextTile] = true
+	for start := range component {
+		if component[start] != 0 || gameMap[start/cols][start%cols].OwnerColor == color {
+			continue
 		}
 
-		if len(q) == 0 && len(mapOfTilesToIgnore) > 1 {
-			tilesFound.Store(true)
+		componentID := len(componentSizes)
+		componentSizes = append(componentSizes, 0)
+		component[start] = componentID
+		q := []int{start}
+
+		for len(q) > 0 {
+			idx := q[0]
+			q = q[1:]
+			componentSizes[componentID]++
 
-			for tile := range mapOfTilesToIgnore {
-				tile.OwnerColor = player.Color
-				tile.IsTail = false
-				player.AllTiles.allTilesLock.Lock()
-				player.AllTiles.AllPlayerTiles = append(player.AllTiles.AllPlayerTiles, tile)
-				player.AllTiles.allTilesLock.Unlock()
+			for _, dir := range Directions {
+				nextRow, nextCol := WrapCoords(idx/cols+dir[0], idx%cols+dir[1])
+				nextIdx := nextRow*cols + nextCol
+				if component[nextIdx] == 0 && gameMap[nextRow][nextCol].OwnerColor != color {
+					component[nextIdx] = componentID
+					q = append(q, nextIdx)
+				}
 			}
 		}
 	}
+
+	outside := 0
+	for componentID, size := range componentSizes {
+		if size > componentSizes[outside] {
+			outside = componentID
+		}
+	}
+
+	enclosed := []*Tile{}
+	for idx, componentID := range component {
+		tile := gameMap[idx/cols][idx%cols]
+		if componentID != 0 && componentID != outside && tile.IsBuildable() {
+			enclosed = append(enclosed, tile)
+		}
+	}
+
+	return enclosed
 }
@@ -0,0 +1,277 @@
+package game
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+// Shapes are drawn with:
+//
+//	.  free ground outside the loop
+//	o  free ground the loop encloses
+//	P  the player's land
+//	t  the player's tail
+//	r  rival land outside the loop
+//	R  rival land the loop encloses
+//	#  wall, never claimed
+//	~  no-build ground, never claimed
+type enclosureCase struct {
+	name  string
+	shape []string
+}
+
+const (
+	testPlayerColor = 1
+	testRivalColor  = 2
+)
+
+// buildShape turns a drawing into a map, the bounds of the player's tiles
+// and the tiles expected to be enclosed.
+func buildShape(t *testing.T, shape []string) ([][]*Tile, *int, tileBounds, [][2]int) {
+	t.Helper()
+	playerColor, rivalColor := testPlayerColor, testRivalColor
+
+	gameMap := make([][]*Tile, len(shape))
+	bounds := newTileBounds()
+	expected := [][2]int{}
+	for row, line := range shape {
+		if len(line) != len(shape[0]) {
+			t.Fatalf("row %d is %d wide, want %d", row, len(line), len(shape[0]))
+		}
+		gameMap[row] = make([]*Tile, len(line))
+		for col, cell := range line {
+			tile := CreateNewTile(row, col)
+			switch cell {
+			case 'P':
+				tile.OwnerColor = &playerColor
+			case 't':
+				tile.OwnerColor, tile.IsTail = &playerColor, true
+			case 'r', 'R':
+				tile.OwnerColor = &rivalColor
+			case '#':
+				tile.Terrain = TerrainWall
+			case '~':
+				tile.Terrain = TerrainNoBuild
+			}
+			if cell == 'P' || cell == 't' {
+				bounds.include(tile)
+			}
+			if cell == 'o' || cell == 'R' {
+				expected = append(expected, [2]int{row, col})
+			}
+			gameMap[row][col] = tile
+		}
+	}
+	return gameMap, &playerColor, bounds, expected
+}
+
+func enclosedCoords(tiles []*Tile) [][2]int {
+	coords := [][2]int{}
+	for _, tile := range tiles {
+		coords = append(coords, [2]int{tile.Y, tile.X})
+	}
+	slices.SortFunc(coords, func(a, b [2]int) int {
+		if a[0] != b[0] {
+			return a[0] - b[0]
+		}
+		return a[1] - b[1]
+	})
+	return coords
+}
+
+func checkEnclosures(t *testing.T, cases []enclosureCase) {
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gameMap, color, bounds, expected := buildShape(t, tc.shape)
+			got := enclosedCoords(findEnclosedTiles(gameMap, color, bounds))
+			if !slices.Equal(got, expected) {
+				t.Errorf("enclosed %v, want %v", got, expected)
+			}
+		})
+	}
+}
+
+func TestFindEnclosedTiles(t *testing.T) {
+	checkEnclosures(t, []enclosureCase{
+		{
+			name: "U-shaped tail closed by territory",
+			shape: []string{
+				"..........",
+				".PPPPPP...",
+				".toooot...",
+				".toooot...",
+				".tttttt...",
+				"..........",
+			},
+		},
+		{
+			name: "spiral",
+			shape: []string{
+				"............",
+				".PPPttttttt.",
+				".PPPoooooot.",
+				"...tttttoot.",
+				".tttttttoot.",
+				".toooooooot.",
+				".toooooooot.",
+				".toooooooot.",
+				".tttttttttt.",
+				"............",
+			},
+		},
+		{
+			name: "loop touching the map border",
+			shape: []string{
+				"PPPP......",
+				"toot......",
+				"toot......",
+				"tttt......",
+				"..........",
+			},
+		},
+		{
+			name: "loop around rival land",
+			shape: []string{
+				"..........",
+				".PPPPP....",
+				".tRRRt....",
+				".tRoot..r.",
+				".ttttt....",
+				"..........",
+			},
+		},
+		{
+			name: "loop around a wall and no-build ground",
+			shape: []string{
+				"..........",
+				".PPPPP....",
+				".to#ot....",
+				".to~ot....",
+				".ttttt....",
+				"..........",
+			},
+		},
+		{
+			name: "tail along the land encloses nothing",
+			shape: []string{
+				"..........",
+				".PPPP.....",
+				".tttt.....",
+				"..........",
+			},
+		},
+		{
+			name: "loop with a gap encloses nothing",
+			shape: []string{
+				"..........",
+				".PPPPP....",
+				".t...t....",
+				".tt.tt....",
+				"..........",
+			},
+		},
+	})
+}
+
+func TestFindEnclosedTilesAcrossTheSeam(t *testing.T) {
+	wrapAround, rows, cols := WrapAround, MapRowCount, MapColCount
+	t.Cleanup(func() { WrapAround, MapRowCount, MapColCount = wrapAround, rows, cols })
+	WrapAround, MapRowCount, MapColCount = true, 10, 10
+
+	checkEnclosures(t, []enclosureCase{
+		{
+			name: "loop across the side seam",
+			shape: []string{
+				"..........",
+				"PPP.....PP",
+				"oot.....to",
+				"ttt.....tt",
+				"..........",
+				"..........",
+				"..........",
+				"..........",
+				"..........",
+				"..........",
+			},
+		},
+		{
+			name: "loop around the corner",
+			shape: []string{
+				"ot......to",
+				"t........t",
+				"..........",
+				"..........",
+				"..........",
+				"..........",
+				"..........",
+				"..........",
+				"P........P",
+				"oP......Po",
+			},
+		},
+		{
+			name: "tail along the seam encloses nothing",
+			shape: []string{
+				"..........",
+				"..........",
+				"..........",
+				"..........",
+				"P........P",
+				"t........t",
+				"..........",
+				"..........",
+				"..........",
+				"..........",
+			},
+		},
+		{
+			name: "pocket on land going all the way around",
+			shape: []string{
+				"..........",
+				"..........",
+				"..........",
+				"..........",
+				"PPPPPPPPPP",
+				"..toot....",
+				"..tttt....",
+				"..........",
+				"..........",
+				"..........",
+			},
+		},
+		{
+			name: "loop away from the seam",
+			shape: []string{
+				"..........",
+				"..........",
+				"...PPPP...",
+				"...toot...",
+				"...tttt...",
+				"..........",
+				"..........",
+				"..........",
+				"..........",
+				"..........",
+			},
+		},
+	})
+}
+
+func TestClaimTailCountsStolenLand(t *testing.T) {
+	world := newStepWorld(t, []string{
+		"........",
+		".PPPP...",
+		".trst...",
+		".tttt...",
+		"........",
+	})
+
+	stolen, claimed := claimTail(world.field.gameMap, world.field.territory, world.player)
+	if claimed != 8 {
+		t.Errorf("claimed %d tiles, want 8", claimed)
+	}
+	if want := map[int]int{testRivalColor: 1}; !maps.Equal(stolen, want) {
+		t.Errorf("stole %v, want %v, a tail isn't land", stolen, want)
+	}
+}
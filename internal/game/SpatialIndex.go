@@ -0,0 +1,278 @@
+package game
+
+import (
+	"math"
+	"sync"
+)
+
+type headEntry struct {
+	tile   *Tile
+	player *Player
+}
+
+type tailEntry struct {
+	tile  *Tile
+	owner *Player
+}
+
+// SpatialIndex buckets player heads and tail segments into a coarse grid so
+// strategies can ask about their neighborhood without walking every player.
+// It is rebuilt once per tick in processGameTick.
+type SpatialIndex struct {
+	indexLock sync.RWMutex
+	cellCols  int
+	cellRows  int
+	heads     [][]headEntry
+	tails     [][]tailEntry
+}
+
+func NewSpatialIndex() *SpatialIndex {
+	cellCols := (MapColCount + spatialCellSize - 1) / spatialCellSize
+	cellRows := (MapRowCount + spatialCellSize - 1) / spatialCellSize
+
+	return &SpatialIndex{
+		cellCols: cellCols,
+		cellRows: cellRows,
+		heads:    make([][]headEntry, cellCols*cellRows),
+		tails:    make([][]tailEntry, cellCols*cellRows),
+	}
+}
+
+func (si *SpatialIndex) cellOf(tile *Tile) int {
+	return (tile.Y/spatialCellSize)*si.cellCols + tile.X/spatialCellSize
+}
+
+// Rebuild drops everything and re-indexes all live players from scratch.
+func (si *SpatialIndex) Rebuild(players *sync.Map) {
+	si.indexLock.Lock()
+	defer si.indexLock.Unlock()
+
+	for i := range si.heads {
+		si.heads[i] = si.heads[i][:0]
+		si.tails[i] = si.tails[i][:0]
+	}
+
+	players.Range(func(_, value interface{}) bool {
+		player, ok := value.(*Player)
+		if !ok || player == nil || player.isDead {
+			return true
+		}
+
+		si.addHeadLocked(player)
+
+		player.Tail.tailLock.Lock()
+		for _, tile := range player.Tail.tailTiles {
+			if tile.IsTail && tile.OwnerColor == player.Color {
+				cell := si.cellOf(tile)
+				si.tails[cell] = append(si.tails[cell], tailEntry{tile: tile, owner: player})
+			}
+		}
+		player.Tail.tailLock.Unlock()
+		return true
+	})
+}
+
+// AddHead registers a freshly spawned player so spawns placed before the
+// next rebuild can already keep their distance.
+func (si *SpatialIndex) AddHead(player *Player) {
+	si.indexLock.Lock()
+	defer si.indexLock.Unlock()
+	si.addHeadLocked(player)
+}
+
+func (si *SpatialIndex) addHeadLocked(player *Player) {
+	head := player.Location
+	cell := si.cellOf(head)
+	si.heads[cell] = append(si.heads[cell], headEntry{tile: head, player: player})
+}
+
+// forEachCellAround calls fn for every cell overlapping the square of the
+// given radius around tile, stopping early when fn returns false.
+func (si *SpatialIndex) forEachCellAround(tile *Tile, radius int, fn func(cell int) bool) {
+	minCellRow := floorDiv(tile.Y-radius, spatialCellSize)
+	maxCellRow := floorDiv(tile.Y+radius, spatialCellSize)
+	minCellCol := floorDiv(tile.X-radius, spatialCellSize)
+	maxCellCol := floorDiv(tile.X+radius, spatialCellSize)
+
+	if !WrapAround {
+		minCellRow, maxCellRow = max(0, minCellRow), min(si.cellRows-1, maxCellRow)
+		minCellCol, maxCellCol = max(0, minCellCol), min(si.cellCols-1, maxCellCol)
+	}
+	// never visit a wrapped cell twice
+	maxCellRow = min(maxCellRow, minCellRow+si.cellRows-1)
+	maxCellCol = min(maxCellCol, minCellCol+si.cellCols-1)
+
+	for cellRow := minCellRow; cellRow <= maxCellRow; cellRow++ {
+		for cellCol := minCellCol; cellCol <= maxCellCol; cellCol++ {
+			if !fn(si.wrapCell(cellRow, cellCol)) {
+				return
+			}
+		}
+	}
+}
+
+// wrapCell turns cell coordinates into a cell index, folding them around the
+// map's edges for the wrap-around arena.
+func (si *SpatialIndex) wrapCell(cellRow int, cellCol int) int {
+	cellRow = ((cellRow % si.cellRows) + si.cellRows) % si.cellRows
+	cellCol = ((cellCol % si.cellCols) + si.cellCols) % si.cellCols
+	return cellRow*si.cellCols + cellCol
+}
+
+func floorDiv(a int, b int) int {
+	if a < 0 {
+		return -((-a + b - 1) / b)
+	}
+	return a / b
+}
+
+// HeadAt returns the player whose head is on tile, skipping excludeColor.
+func (si *SpatialIndex) HeadAt(tile *Tile, excludeColor int) *Player {
+	si.indexLock.RLock()
+	defer si.indexLock.RUnlock()
+
+	for _, head := range si.heads[si.cellOf(tile)] {
+		if head.tile == tile && *head.player.Color != excludeColor && !head.player.isDead {
+			return head.player
+		}
+	}
+	return nil
+}
+
+// HeadsWithin returns every live head within Manhattan radius of tile.
+func (si *SpatialIndex) HeadsWithin(tile *Tile, radius int, excludeColor int) []*Player {
+	si.indexLock.RLock()
+	defer si.indexLock.RUnlock()
+
+	result := []*Player{}
+	si.forEachCellAround(tile, radius, func(cell int) bool {
+		for _, head := range si.heads[cell] {
+			if *head.player.Color == excludeColor || head.player.isDead {
+				continue
+			}
+			if GetManhattanDistance(tile, head.tile) <= radius {
+				result = append(result, head.player)
+			}
+		}
+		return true
+	})
+	return result
+}
+
+// HeadsNearTiles maps every live head within Manhattan radius of any of the
+// tiles to its distance from the closest one. The cells around all the tiles
+// are gathered first, so a long tail costs one pass over the index instead of
+// a query per tile.
+func (si *SpatialIndex) HeadsNearTiles(tiles []*Tile, radius int, excludeColor int) map[*Player]int {
+	si.indexLock.RLock()
+	defer si.indexLock.RUnlock()
+
+	// one tile per cell is enough to find the cells around it, the cell's
+	// own size is added to the radius to cover the rest of it
+	tileCells := map[int]bool{}
+	nearbyCells := map[int]bool{}
+	for _, tile := range tiles {
+		cell := si.cellOf(tile)
+		if tileCells[cell] {
+			continue
+		}
+		tileCells[cell] = true
+		si.forEachCellAround(tile, radius+spatialCellSize, func(cell int) bool {
+			nearbyCells[cell] = true
+			return true
+		})
+	}
+
+	result := make(map[*Player]int)
+	for cell := range nearbyCells {
+		for _, head := range si.heads[cell] {
+			if *head.player.Color == excludeColor || head.player.isDead {
+				continue
+			}
+			for _, tile := range tiles {
+				dist := GetManhattanDistance(tile, head.tile)
+				if knownDist, ok := result[head.player]; dist <= radius && (!ok || dist < knownDist) {
+					result[head.player] = dist
+				}
+			}
+		}
+	}
+	return result
+}
+
+// TailsWithin returns every live tail segment within Manhattan radius of tile.
+func (si *SpatialIndex) TailsWithin(tile *Tile, radius int, excludeColor int) []*Tile {
+	si.indexLock.RLock()
+	defer si.indexLock.RUnlock()
+
+	result := []*Tile{}
+	si.forEachCellAround(tile, radius, func(cell int) bool {
+		for _, tail := range si.tails[cell] {
+			if *tail.owner.Color == excludeColor || tail.owner.isDead {
+				continue
+			}
+			if GetManhattanDistance(tile, tail.tile) <= radius {
+				result = append(result, tail.tile)
+			}
+		}
+		return true
+	})
+	return result
+}
+
+// NearestHead searches outwards ring by ring for the closest live head.
+// A negative maxRadius means the whole map is searched.
+func (si *SpatialIndex) NearestHead(tile *Tile, excludeColor int, maxRadius int) (*Player, int) {
+	si.indexLock.RLock()
+	defer si.indexLock.RUnlock()
+
+	if maxRadius < 0 {
+		maxRadius = MapColCount + MapRowCount
+	}
+
+	var nearest *Player
+	nearestDist := math.MaxInt32
+	originCellRow, originCellCol := tile.Y/spatialCellSize, tile.X/spatialCellSize
+	maxRing := maxRadius/spatialCellSize + 1
+
+	if WrapAround {
+		// past half the map the rings start meeting themselves around the back
+		maxRing = min(maxRing, max(si.cellRows, si.cellCols)/2+1)
+	}
+
+	for ring := 0; ring <= maxRing; ring++ {
+		for cellRow := originCellRow - ring; cellRow <= originCellRow+ring; cellRow++ {
+			if !WrapAround && (cellRow < 0 || cellRow >= si.cellRows) {
+				continue
+			}
+			for cellCol := originCellCol - ring; cellCol <= originCellCol+ring; cellCol++ {
+				if !WrapAround && (cellCol < 0 || cellCol >= si.cellCols) {
+					continue
+				}
+				// only the border of the ring is new
+				if cellRow != originCellRow-ring && cellRow != originCellRow+ring &&
+					cellCol != originCellCol-ring && cellCol != originCellCol+ring {
+					continue
+				}
+
+				for _, head := range si.heads[si.wrapCell(cellRow, cellCol)] {
+					if *head.player.Color == excludeColor || head.player.isDead {
+						continue
+					}
+					dist := GetManhattanDistance(tile, head.tile)
+					if dist < nearestDist && dist <= maxRadius {
+						nearestDist = dist
+						nearest = head.player
+					}
+				}
+			}
+		}
+
+		// anything in the next ring is at least this far away
+		if nearest != nil && nearestDist <= ring*spatialCellSize {
+			break
+		}
+	}
+
+	return nearest, nearestDist
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// TerritoryCounter is the authoritative count of claimed (non tail) tiles per
+// color. Every ownership change goes through setTileOwner so it never has to
+// be recomputed by walking the map.
+type TerritoryCounter struct {
+	counts   [256]atomic.Int64
+	versions *MapVersions // marked on every change, nil for maps nobody renders by version
+}
+
+var territoryCounter = &TerritoryCounter{}
+
+func (tc *TerritoryCounter) Get(color int) int {
+	if color < 0 || color >= len(tc.counts) {
+		return 0
+	}
+	return int(tc.counts[color].Load())
+}
+
+func (tc *TerritoryCounter) add(color int, delta int) {
+	if color < 0 || color >= len(tc.counts) {
+		return
+	}
+	tc.counts[color].Add(int64(delta))
+}
+
+func (tc *TerritoryCounter) reset(color int) {
+	if color < 0 || color >= len(tc.counts) {
+		return
+	}
+	tc.counts[color].Store(0)
+}
+
+// setTileOwner changes who owns a tile and keeps the land counters in sync.
+func (tc *TerritoryCounter) setTileOwner(tile *Tile, color *int, isTail bool) {
+	if tile.OwnerColor != nil && !tile.IsTail {
+		tc.add(*tile.OwnerColor, -1)
+	}
+
+	tile.OwnerColor = color
+	tile.IsTail = isTail
+	tc.versions.markDirty(tile)
+
+	if color != nil && !isTail {
+		tc.add(*color, 1)
+	}
+}
+
+// LandPercent is how much of the map a number of tiles covers, in percent.
+func LandPercent(tiles float64) float64 {
+	return tiles * 100 / float64(MapColCount*MapRowCount)
+}
+
+type PlayerStanding struct {
+	Name  string
+	Color int
+	Land  int
+	IsBot bool
+}
+
+// TickSnapshot is computed once at the end of every tick and shared by all
+// sessions, so per-session rendering doesn't have to walk the players.
+type TickSnapshot struct {
+	Tick       uint64
+	Standings  []PlayerStanding // sorted by land, biggest first
+	HumanCount int
+	BotCount   int
+	Minimap    *Minimap
+	Radar      map[int][]RadarContact // by human player color
+}
+
+func (gm *GameManager) buildTickSnapshot(tick uint64) *TickSnapshot {
+	snapshot := &TickSnapshot{Tick: tick, Minimap: gm.GetTickSnapshot().Minimap, Radar: map[int][]RadarContact{}}
+	if snapshot.Minimap == nil || tick%minimapRefreshTicks == 0 {
+		snapshot.Minimap = gm.buildMinimap()
+	}
+
+	gm.Players.Range(func(_, value interface{}) bool {
+		player, ok := value.(*Player)
+		if !ok || player == nil || player.isDead {
+			return true
+		}
+
+		player.ClaimedEstate = gm.Territory.Get(*player.Color)
+		snapshot.Standings = append(snapshot.Standings, PlayerStanding{
+			Name:  player.Name,
+			Color: *player.Color,
+			Land:  player.ClaimedEstate,
+			IsBot: player.BotStrategy != nil,
+		})
+
+		if player.BotStrategy != nil {
+			snapshot.BotCount++
+		} else {
+			snapshot.HumanCount++
+			snapshot.Radar[*player.Color] = gm.radarContacts(player)
+			player.Tail.tailLock.Lock()
+			tailLength := len(player.Tail.tailTiles)
+			player.Tail.tailLock.Unlock()
+			player.History.sample(tick, player.ClaimedEstate, tailLength)
+		}
+		return true
+	})
+
+	sort.Slice(snapshot.Standings, func(i, j int) bool {
+		return snapshot.Standings[i].Land > snapshot.Standings[j].Land
+	})
+
+	return snapshot
+}
+
+// GetTickSnapshot returns the latest snapshot, never nil.
+func (gm *GameManager) GetTickSnapshot() *TickSnapshot {
+	if snapshot := gm.tickSnapshot.Load(); snapshot != nil {
+		return snapshot
+	}
+	return &TickSnapshot{}
+}
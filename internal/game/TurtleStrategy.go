@@ -0,0 +1,49 @@
+package game
+
+const (
+	turtleLoopLeg         = 6
+	turtleHomeSearchDepth = 30
+)
+
+// TurtleStrategy makes small rectangular loops next to its own territory and
+// heads home as soon as a loop is long enough or someone gets close.
+type TurtleStrategy struct {
+	DefaultStrategy
+}
+
+func (s *TurtleStrategy) getNextBestDirection(player *Player, gm *GameManager) Direction {
+	if player.isDead {
+		return Direction{}
+	}
+
+	validMoves := getValidMoves(player, gm)
+	if len(validMoves) == 0 {
+		return player.CurrentDirection
+	}
+
+	tailLength := len(player.Tail.tailTiles)
+	isThreatened := s.calculateThreatScore(player, gm) > 0
+
+	if tailLength >= 2*turtleLoopLeg || (isThreatened && tailLength > 1) {
+		home, _ := s.findNearestOwnedTile(player.Location, player.Color, gm, turtleHomeSearchDepth, false, nil)
+		if home == nil {
+			return s.DefaultStrategy.getNextBestDirection(player, gm)
+		}
+		return getDirectionTowards(player, home, validMoves)
+	}
+
+	// turn clockwise once per leg so the loop stays a tight rectangle
+	if tailLength > 0 && tailLength%turtleLoopLeg == 0 {
+		turned := Direction{Dx: -player.CurrentDirection.Dy, Dy: player.CurrentDirection.Dx, PlayerColor: *player.Color}
+		if _, ok := validMoves[turned]; ok {
+			return turned
+		}
+	}
+
+	straight := Direction{Dx: player.CurrentDirection.Dx, Dy: player.CurrentDirection.Dy, PlayerColor: *player.Color}
+	if _, ok := validMoves[straight]; ok {
+		return straight
+	}
+
+	return s.DefaultStrategy.getNextBestDirection(player, gm)
+}
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Mshel/ouroboros/internal/game"
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	chatHistorySize = 5
+	chatErrorColor  = 196
+)
+
+func newChatInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "say something, /mute name"
+	ti.Prompt = "> "
+	ti.CharLimit = game.ChatMaxLength
+	ti.Cursor.SetMode(cursor.CursorStatic)
+	return ti
+}
+
+// updateChat takes the keys while the chat input is open. Enter sends, Esc
+// closes it, /mute and /unmute manage who you hear.
+func (m GameViewModel) updateChat(msg tea.KeyMsg, currentPlayer *game.Player) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return m.closeChat(), nil
+
+	case "enter":
+		text := strings.TrimSpace(m.chatInput.Value())
+		command, name, _ := strings.Cut(text, " ")
+		name = strings.TrimSpace(name)
+
+		switch {
+		case text == "":
+			return m.closeChat(), nil
+		case command == "/mute" && name != "":
+			m.gameManager.Chat.Mute(m.UserSession, name)
+			m.ChatLog = appendChatLine(m.ChatLog, chatLine{Text: fmt.Sprintf("muted %s", name)})
+		case command == "/unmute" && name != "":
+			m.gameManager.Chat.Unmute(m.UserSession, name)
+			m.ChatLog = appendChatLine(m.ChatLog, chatLine{Text: fmt.Sprintf("unmuted %s", name)})
+		default:
+			if err := m.gameManager.Chat.Send(currentPlayer, m.chatInput.Value()); err != nil {
+				m.chatError = err.Error()
+				return m, nil
+			}
+		}
+		return m.closeChat(), nil
+	}
+
+	var cmd tea.Cmd
+	m.chatInput, cmd = m.chatInput.Update(msg)
+	m.chatError = ""
+	return m, cmd
+}
+
+func (m GameViewModel) openChat() GameViewModel {
+	m.chatOpen = true
+	m.chatInput.Focus()
+	return m
+}
+
+func (m GameViewModel) closeChat() GameViewModel {
+	m.chatOpen = false
+	m.chatError = ""
+	m.chatInput.Reset()
+	m.chatInput.Blur()
+	return m
+}
+
+// chatLine is a received message, or a note from the ui itself when Name is empty.
+type chatLine struct {
+	Name  string
+	Color int
+	Text  string
+}
+
+func appendChatLine(chatLog []chatLine, line chatLine) []chatLine {
+	chatLog = append(chatLog, line)
+	if len(chatLog) > chatHistorySize {
+		chatLog = chatLog[len(chatLog)-chatHistorySize:]
+	}
+	return chatLog
+}
+
+// chatLineCount is how many status panel lines renderChat will take.
+func (m GameViewModel) chatLineCount() int {
+	if len(m.ChatLog) == 0 && !m.chatOpen {
+		return 0
+	}
+
+	lines := 2 + len(m.ChatLog)
+	if m.chatOpen {
+		lines++
+	}
+	if m.chatError != "" {
+		lines++
+	}
+	return lines
+}
+
+func (m GameViewModel) renderChat(width int) string {
+	if m.chatLineCount() == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	lineStyle := m.theme.NewStyle().MaxWidth(width)
+
+	sb.WriteString("\n" + m.theme.NewStyle().Bold(true).Render("--- Chat ---") + "\n")
+	for _, line := range m.ChatLog {
+		if line.Name == "" {
+			sb.WriteString(lineStyle.Faint(true).Render(line.Text) + "\n")
+			continue
+		}
+		nameStyle := m.theme.NewStyle().Foreground(m.theme.Color(line.Color))
+		sb.WriteString(lineStyle.Render(nameStyle.Render(line.Name+": ")+line.Text) + "\n")
+	}
+
+	if m.chatOpen {
+		input := m.chatInput
+		input.Width = max(1, width-len(input.Prompt)-1)
+		sb.WriteString(input.View() + "\n")
+	}
+	if m.chatError != "" {
+		errorStyle := lineStyle.Foreground(m.theme.Color(chatErrorColor))
+		sb.WriteString(errorStyle.Render(m.chatError) + "\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"slices"
+)
+
+// ColorblindMode is the accessibility setting picked on the setup screen. Every
+// mode but the standard one draws rivals with patterns and outlines your land,
+// the last two also shift colors so they stay apart for red-green blindness.
+type ColorblindMode int
+
+const (
+	ColorblindOff ColorblindMode = iota
+	ColorblindPatterns
+	ColorblindDeuteranopia
+	ColorblindProtanopia
+)
+
+var colorblindModeNames = []string{"standard", "patterns", "deuteranopia", "protanopia"}
+
+func (mode ColorblindMode) String() string {
+	return colorblindModeNames[mode]
+}
+
+// ParseColorblindMode reads a saved mode, anything unknown is the standard one.
+func ParseColorblindMode(name string) ColorblindMode {
+	if index := slices.Index(colorblindModeNames, name); index >= 0 {
+		return ColorblindMode(index)
+	}
+	return ColorblindOff
+}
+
+func (mode ColorblindMode) next(step int) ColorblindMode {
+	count := len(colorblindModeNames)
+	return ColorblindMode(((int(mode)+step)%count + count) % count)
+}
+
+const ownBorderRune = "█"
+
+// rivalGlyphs are the territory patterns handed to rivals on screen, none of
+// them is claimedEstateRune or ownBorderRune so your land stays unmistakable.
+var rivalGlyphs = []string{"░", "▓", "▚", "▞", "▙", "▟", "▛", "▜", "#", "%", "+", "x", "=", "*", "~", "o"}
+
+type tailStyle struct {
+	vertical, horizontal                 string
+	upRight, upLeft, downRight, downLeft string
+	single                               string
+}
+
+// tailStyles[0] is what everybody's tail looks like normally. With patterns
+// on it is kept for your own tail and rivals get the others.
+var tailStyles = []tailStyle{
+	{"│", "─", "└", "┘", "┌", "┐", "•"},
+	{"┃", "━", "┗", "┛", "┏", "┓", "▪"},
+	{"║", "═", "╚", "╝", "╔", "╗", "◦"},
+	{"┆", "┄", "╰", "╯", "╭", "╮", "∙"},
+}
+
+// rivalPatterns keeps the pattern of every rival on screen for as long as
+// they stay visible, newcomers get the lowest free one.
+type rivalPatterns struct {
+	assigned map[int]int
+}
+
+func newRivalPatterns() *rivalPatterns {
+	return &rivalPatterns{assigned: make(map[int]int)}
+}
+
+func (rp *rivalPatterns) update(visibleColors []int) {
+	visible := make(map[int]bool, len(visibleColors))
+	for _, color := range visibleColors {
+		visible[color] = true
+	}
+	for color := range rp.assigned {
+		if !visible[color] {
+			delete(rp.assigned, color)
+		}
+	}
+
+	slices.Sort(visibleColors)
+	for _, color := range visibleColors {
+		if _, ok := rp.assigned[color]; ok {
+			continue
+		}
+		taken := make(map[int]bool, len(rp.assigned))
+		for _, pattern := range rp.assigned {
+			taken[pattern] = true
+		}
+		pattern := 0
+		for taken[pattern] && pattern < len(rivalGlyphs) {
+			pattern++
+		}
+		// more rivals than patterns, they have to start sharing
+		if pattern == len(rivalGlyphs) {
+			pattern = len(rp.assigned) % len(rivalGlyphs)
+		}
+		rp.assigned[color] = pattern
+	}
+}
+
+func (rp *rivalPatterns) pattern(color int) (int, bool) {
+	pattern, ok := rp.assigned[color]
+	return pattern, ok
+}
+
+func rivalGlyph(pattern int) string {
+	return rivalGlyphs[pattern%len(rivalGlyphs)]
+}
+
+func rivalTailStyle(pattern int) tailStyle {
+	return tailStyles[1+pattern%(len(tailStyles)-1)]
+}
+
+// daltonize shifts what a red-green blind viewer can't see into the channels
+// they can, see Fidaner, Lin and Ozguven, "Analysis of Color Blindness".
+func daltonize(mode ColorblindMode, r, g, b uint8) (uint8, uint8, uint8) {
+	red, green, blue := float64(r), float64(g), float64(b)
+
+	l := 17.8824*red + 43.5161*green + 4.11935*blue
+	m := 3.45565*red + 27.1554*green + 3.86714*blue
+	s := 0.0299566*red + 0.184309*green + 1.46709*blue
+
+	switch mode {
+	case ColorblindProtanopia:
+		l = 2.02344*m - 2.52581*s
+	case ColorblindDeuteranopia:
+		m = 0.494207*l + 1.24827*s
+	default:
+		return r, g, b
+	}
+
+	seenRed := 0.0809444479*l - 0.130504409*m + 0.116721066*s
+	seenGreen := -0.0102485335*l + 0.0540193266*m - 0.113614708*s
+	seenBlue := -0.000365296938*l - 0.00412161469*m + 0.693511405*s
+
+	errorRed, errorGreen, errorBlue := red-seenRed, green-seenGreen, blue-seenBlue
+
+	return clampChannel(red),
+		clampChannel(green + 0.7*errorRed + errorGreen),
+		clampChannel(blue + 0.7*errorRed + errorBlue)
+}
+
+func clampChannel(value float64) uint8 {
+	return uint8(min(255, max(0, value+0.5)))
+}
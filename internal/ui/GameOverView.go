@@ -8,6 +8,7 @@ import (
 
 	// Added for date formatting
 	"github.com/Mshel/ouroboros/internal/game"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -26,6 +27,28 @@ type LeaderboardScoresMsg struct {
 	Err         error
 }
 
+type LeaderboardMyRankMsg struct {
+	Rank  int
+	Found bool
+	Err   error
+}
+
+type LeaderboardTimelineMsg struct {
+	Score   game.Score
+	Summary game.LifeSummary
+	Found   bool
+	Err     error
+}
+
+var leaderboardWindows = []struct {
+	window game.ScoreWindow
+	name   string
+}{
+	{game.ScoreWindowToday, "Today"},
+	{game.ScoreWindowWeek, "This week"},
+	{game.ScoreWindowAllTime, "All time"},
+}
+
 var (
 	leaderboardHeaderStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("15")).
@@ -37,26 +60,38 @@ var (
 				Padding(0, 1)
 )
 
+const (
+	leaderboardDateMinWidth = 70
+	leaderboardSearchLimit  = 20 // names are never longer
+	gameOverRoomyHeight     = 40 // below this the title gives up its padding
+)
+
 type GameOverModel struct {
 	tea.Model
 	GameManager     *game.GameManager
 	FinalEstate     float64
 	FinalKills      int
+	FinalStolen     int
 	SelectedButton  int
 	LeaderboardData []PlayerScore
 	EstateInfo      map[*int]int
+	Summary         *game.LifeSummary
+	theme           *Theme
 	ScreenWidth     int
 	ScreenHeight    int
 }
 
-func NewGameOverModel(gm *game.GameManager, finalEstate float64, finalKills int, lbData []PlayerScore, estateInfo map[*int]int, screenWidth, screenHeight int) GameOverModel {
+func NewGameOverModel(gm *game.GameManager, finalEstate float64, finalKills int, finalStolen int, lbData []PlayerScore, estateInfo map[*int]int, summary *game.LifeSummary, theme *Theme, screenWidth, screenHeight int) GameOverModel {
 	return GameOverModel{
 		GameManager:     gm,
 		FinalEstate:     finalEstate,
 		FinalKills:      finalKills,
+		FinalStolen:     finalStolen,
 		SelectedButton:  0, // Default to EXIT
 		LeaderboardData: lbData,
 		EstateInfo:      estateInfo,
+		Summary:         summary,
+		theme:           theme,
 		ScreenWidth:     screenWidth,
 		ScreenHeight:    screenHeight,
 	}
@@ -67,6 +102,12 @@ func (m GameOverModel) Init() tea.Cmd {
 }
 
 func (m GameOverModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		m.ScreenWidth = msg.Width
+		m.ScreenHeight = msg.Height
+		return m, nil
+	}
+
 	if msg, ok := msg.(tea.KeyMsg); ok {
 		switch msg.String() {
 		case "left", "h":
@@ -92,14 +133,18 @@ func (m GameOverModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m GameOverModel) View() string {
-	messageStyle := lipgloss.NewStyle().
+	messageStyle := m.theme.NewStyle().
 		Padding(2, 5).
 		Align(lipgloss.Center).
 		Width(m.ScreenWidth - 4)
+	// the timeline needs the room more than the title does
+	if m.Summary != nil && m.ScreenHeight < gameOverRoomyHeight {
+		messageStyle = messageStyle.Padding(0, 5)
+	}
 
 	title := messageStyle.Render(" Good Game! ")
 
-	rankStyle := lipgloss.NewStyle().
+	rankStyle := m.theme.NewStyle().
 		Foreground(lipgloss.Color("220")). // Yellow
 		Bold(true).
 		Underline(true)
@@ -116,24 +161,40 @@ func (m GameOverModel) View() string {
 		rankContent.WriteString("Your rank could not be determined.\n\n")
 	}
 
-	stats := fmt.Sprintf("\nFinal Stats:\n Land Claimed: %.2f%% \nPlayer Kills: %d\n\n", m.FinalEstate, m.FinalKills)
+	stats := fmt.Sprintf("\nFinal Stats:\n Land Claimed: %.2f%% \nPlayer Kills: %d\nTiles Stolen: %d\n\n", m.FinalEstate, m.FinalKills, m.FinalStolen)
+	if m.Summary != nil {
+		stats = fmt.Sprintf("\nFinal Stats:\nLand Claimed: %.2f%%  Peak: %.2f%%\nKills: %d  Tiles Stolen: %d\nTime Alive: %s  Loops Closed: %d  Longest Tail: %d\n",
+			m.FinalEstate, game.LandPercent(float64(m.Summary.PeakTerritory)),
+			m.FinalKills, m.FinalStolen,
+			formatTicks(uint64(m.Summary.TimeAlive/game.GameTickDuration)), m.Summary.LoopsClosed, m.Summary.LongestTail)
+	}
+
+	button := m.theme.Bind(buttonStyle)
+	selectedButton := m.theme.Bind(submitButtonStyle)
 
-	exitButton := buttonStyle.Render("EXIT (Enter)")
-	leaderboardButton := buttonStyle.Render("LEADERBOARD")
+	exitButton := button.Render("EXIT (Enter)")
+	leaderboardButton := button.Render("LEADERBOARD")
 
 	if m.SelectedButton == 0 {
-		exitButton = submitButtonStyle.Render("EXIT (Enter)")
+		exitButton = selectedButton.Render("EXIT (Enter)")
 	} else {
-		leaderboardButton = submitButtonStyle.Render("LEADERBOARD")
+		leaderboardButton = selectedButton.Render("LEADERBOARD")
 	}
 
 	buttons := lipgloss.JoinHorizontal(lipgloss.Center, exitButton, leaderboardButton)
 
 	content := lipgloss.JoinVertical(lipgloss.Center, title, rankContent.String(), stats, buttons)
+	if m.Summary != nil {
+		// the border takes two lines, the timeline gets whatever else is left
+		timelineLines := m.ScreenHeight - lipgloss.Height(content) - 2 - 1
+		if timeline := renderTimeline(m.theme, m.Summary, m.ScreenWidth-8, timelineLines); timeline != "" {
+			content = lipgloss.JoinVertical(lipgloss.Center, title, rankContent.String(), stats, timeline+"\n", buttons)
+		}
+	}
 
-	return lipgloss.Place(m.ScreenWidth, m.ScreenHeight,
+	return m.theme.Renderer.Place(m.ScreenWidth, m.ScreenHeight,
 		lipgloss.Center, lipgloss.Center,
-		lipgloss.NewStyle().Border(lipgloss.ThickBorder()).Render(content),
+		m.theme.NewStyle().Border(lipgloss.ThickBorder()).Render(content),
 	)
 }
 
@@ -144,6 +205,17 @@ type LeaderboardModel struct {
 	TotalScores      int
 	CurrentPage      int
 	PageSize         int
+	Query            game.ScoreQuery
+	windowIndex      int
+	playerName       string // empty until the player joins a game, there is no rank to jump to
+	highlightRank    int    // the rank "my rank" jumped to, selected once its page loads
+	selectedRow      int    // the row enter opens the timeline of
+	timeline         *game.LifeSummary
+	timelineScore    game.Score
+	searchInput      textinput.Model
+	searching        bool
+	notice           string
+	theme            *Theme
 	ScreenWidth      int
 	ScreenHeight     int
 	Loading          bool
@@ -154,14 +226,14 @@ func fetchScoresCmd(m LeaderboardModel) tea.Cmd {
 	return func() tea.Msg {
 		offset := (m.CurrentPage - 1) * m.PageSize
 
-		scores, err := m.HighScoreService.GetHighScores(m.PageSize, offset)
+		scores, err := m.HighScoreService.GetHighScores(m.Query, m.PageSize, offset)
 		if err != nil {
 			return LeaderboardScoresMsg{Err: fmt.Errorf("failed to fetch scores: %w", err)}
 		}
 
 		totalCount := m.TotalScores
 		if m.TotalScores == 0 || m.CurrentPage == 1 {
-			count, err := m.HighScoreService.GetTotalScoreCount()
+			count, err := m.HighScoreService.GetTotalScoreCount(m.Query)
 			if err != nil {
 				return LeaderboardScoresMsg{Scores: scores, TotalScores: len(scores), Err: fmt.Errorf("could not get total count: %w", err)}
 			}
@@ -172,17 +244,79 @@ func fetchScoresCmd(m LeaderboardModel) tea.Cmd {
 	}
 }
 
-func NewLeaderboardModel(hss *game.HighScoreService, screenWidth, screenHeight int) LeaderboardModel {
+func fetchMyRankCmd(m LeaderboardModel) tea.Cmd {
+	return func() tea.Msg {
+		rank, found, err := m.HighScoreService.GetPlayerBestRank(m.Query, m.playerName)
+		return LeaderboardMyRankMsg{Rank: rank, Found: found, Err: err}
+	}
+}
+
+func fetchTimelineCmd(m LeaderboardModel, score game.Score) tea.Cmd {
+	return func() tea.Msg {
+		summary, found, err := m.HighScoreService.GetLifeSummary(score.ID)
+		return LeaderboardTimelineMsg{Score: score, Summary: summary, Found: found, Err: err}
+	}
+}
+
+func NewLeaderboardModel(hss *game.HighScoreService, playerName string, theme *Theme, screenWidth, screenHeight int) LeaderboardModel {
+	ti := textinput.New()
+	ti.Placeholder = "part of a name"
+	ti.CharLimit = leaderboardSearchLimit
+	ti.Width = leaderboardSearchLimit
+	ti.PromptStyle = focusedStyle
+	ti.TextStyle = focusedStyle
+	theme.bindTextInput(&ti)
+
+	windowIndex := len(leaderboardWindows) - 1 // all time
+
 	return LeaderboardModel{
 		HighScoreService: hss,
 		CurrentPage:      1,
 		PageSize:         10, // Default page size
+		Query:            game.ScoreQuery{Window: leaderboardWindows[windowIndex].window, SortBy: game.ScoreSortLand},
+		windowIndex:      windowIndex,
+		playerName:       playerName,
+		searchInput:      ti,
+		theme:            theme,
 		ScreenWidth:      screenWidth,
 		ScreenHeight:     screenHeight,
 		Loading:          true,
 	}
 }
 
+// refetch starts over from the first page after the query changed.
+func (m LeaderboardModel) refetch() (LeaderboardModel, tea.Cmd) {
+	m.CurrentPage = 1
+	m.TotalScores = 0
+	m.highlightRank = 0
+	m.selectedRow = 0
+	m.Loading = true
+	return m, fetchScoresCmd(m)
+}
+
+func (m LeaderboardModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.searching = false
+		m.searchInput.Blur()
+		m.Query.NameSearch = strings.TrimSpace(m.searchInput.Value())
+		return m.refetch()
+	case "esc":
+		m.searching = false
+		m.searchInput.Blur()
+		m.searchInput.SetValue("")
+		if m.Query.NameSearch == "" {
+			return m, nil
+		}
+		m.Query.NameSearch = ""
+		return m.refetch()
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	return m, cmd
+}
+
 func (m LeaderboardModel) Init() tea.Cmd {
 	return fetchScoresCmd(m)
 }
@@ -191,6 +325,11 @@ func (m LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.ScreenWidth = msg.Width
+		m.ScreenHeight = msg.Height
+		return m, nil
+
 	case LeaderboardScoresMsg:
 		m.Loading = false
 		m.Error = msg.Err
@@ -198,16 +337,91 @@ func (m LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.Scores = msg.Scores
 			m.TotalScores = msg.TotalScores
 		}
+		m.selectedRow = max(0, min(m.selectedRow, len(m.Scores)-1))
+		for row, score := range m.Scores {
+			if score.Rank == m.highlightRank {
+				m.selectedRow = row
+			}
+		}
+		m.highlightRank = 0
 		return m, nil
 
+	case LeaderboardTimelineMsg:
+		switch {
+		case msg.Err != nil:
+			m.notice = "Couldn't load that run's timeline."
+		case !msg.Found:
+			m.notice = "No timeline was kept for that run."
+		default:
+			m.timeline, m.timelineScore = &msg.Summary, msg.Score
+		}
+		return m, nil
+
+	case LeaderboardMyRankMsg:
+		if msg.Err != nil || !msg.Found {
+			m.notice = "No score of yours in this period yet."
+			return m, nil
+		}
+		m.Query.NameSearch = ""
+		m.searchInput.SetValue("")
+		m.CurrentPage = (msg.Rank-1)/m.PageSize + 1
+		m.TotalScores = 0
+		m.highlightRank = msg.Rank
+		m.Loading = true
+		return m, fetchScoresCmd(m)
+
 	case tea.KeyMsg:
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		if m.timeline != nil {
+			if msg.String() == "esc" || msg.String() == "enter" {
+				m.timeline = nil
+			}
+			return m, nil
+		}
+
+		m.notice = ""
 		switch msg.String() {
-		case "esc", "enter":
+		case "up", "k":
+			m.selectedRow = max(0, m.selectedRow-1)
+		case "down", "j":
+			m.selectedRow = max(0, min(len(m.Scores)-1, m.selectedRow+1))
+		case "enter":
+			if m.selectedRow < len(m.Scores) {
+				return m, fetchTimelineCmd(m, m.Scores[m.selectedRow])
+			}
+		case "tab", "shift+tab":
+			step := 1
+			if msg.String() == "shift+tab" {
+				step = len(leaderboardWindows) - 1
+			}
+			m.windowIndex = (m.windowIndex + step) % len(leaderboardWindows)
+			m.Query.Window = leaderboardWindows[m.windowIndex].window
+			return m.refetch()
+		case "s":
+			if m.Query.SortBy == game.ScoreSortLand {
+				m.Query.SortBy = game.ScoreSortKills
+			} else {
+				m.Query.SortBy = game.ScoreSortLand
+			}
+			return m.refetch()
+		case "/":
+			m.searching = true
+			return m, m.searchInput.Focus()
+		case "m":
+			if m.playerName == "" {
+				m.notice = "Join a game first, your rank goes by the name you play under."
+				return m, nil
+			}
+			return m, fetchMyRankCmd(m)
+		case "esc":
 			// Signal the Controller to change screen back to Game Over or Intro
 			return m, func() tea.Msg { return ReturnFromLeaderboardMsg{} }
 		case "left", "h":
 			if m.CurrentPage > 1 {
 				m.CurrentPage--
+				m.selectedRow = 0
 				m.Loading = true
 				return m, fetchScoresCmd(m)
 			}
@@ -218,6 +432,7 @@ func (m LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			if m.CurrentPage < totalPages {
 				m.CurrentPage++
+				m.selectedRow = 0
 				m.Loading = true
 				return m, fetchScoresCmd(m)
 			}
@@ -228,20 +443,24 @@ func (m LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m LeaderboardModel) View() string {
 	if m.Loading {
-		return lipgloss.Place(m.ScreenWidth, m.ScreenHeight,
+		return m.theme.Renderer.Place(m.ScreenWidth, m.ScreenHeight,
 			lipgloss.Center, lipgloss.Center,
-			lipgloss.NewStyle().Border(lipgloss.ThickBorder()).Render("Loading Leaderboard..."),
+			m.theme.NewStyle().Border(lipgloss.ThickBorder()).Render("Loading Leaderboard..."),
 		)
 	}
 
 	if m.Error != nil {
-		return lipgloss.Place(m.ScreenWidth, m.ScreenHeight,
+		return m.theme.Renderer.Place(m.ScreenWidth, m.ScreenHeight,
 			lipgloss.Center, lipgloss.Center,
 			// Changed Foreground("9") (Red) to Foreground("15") (Light Gray/White)
-			lipgloss.NewStyle().Border(lipgloss.ThickBorder()).Foreground(lipgloss.Color("15")).Render(fmt.Sprintf("Error loading scores: %s", m.Error)),
+			m.theme.NewStyle().Border(lipgloss.ThickBorder()).Foreground(lipgloss.Color("15")).Render(fmt.Sprintf("Error loading scores: %s", m.Error)),
 		)
 	}
 
+	if m.timeline != nil {
+		return m.renderScoreTimeline()
+	}
+
 	var tableContent strings.Builder
 
 	// Define Column Widths
@@ -249,25 +468,36 @@ func (m LeaderboardModel) View() string {
 	nameWidth := 15
 	estateWidth := 12 // Land Claimed (REAL)
 	killsWidth := 7   // Increased from 7 to 9 to add a slight gap
-	dateWidth := 15   // Increased from 15 to 17 to accommodate the shift and ensure alignment
+	stolenWidth := 9
+	dateWidth := 15 // Increased from 15 to 17 to accommodate the shift and ensure alignment
+	// narrow terminals lose the date column
+	showDate := m.ScreenWidth >= leaderboardDateMinWidth
 
 	// 1. Header Row
-	header := lipgloss.JoinHorizontal(lipgloss.Top,
-		leaderboardHeaderStyle.Width(rankWidth).Render("#"),
-		leaderboardHeaderStyle.Width(nameWidth).Render("Player"),
-		leaderboardHeaderStyle.Width(estateWidth).Render("Land (%)"),
-		leaderboardHeaderStyle.Width(killsWidth).Render("Kills"),
-		leaderboardHeaderStyle.Width(dateWidth).Render("Date"),
-	)
+	headerStyle := m.theme.Bind(leaderboardHeaderStyle)
+	headerCells := []string{
+		headerStyle.Width(rankWidth).Render("#"),
+		headerStyle.Width(nameWidth).Render("Player"),
+		headerStyle.Width(estateWidth).Render("Land (%)"),
+		headerStyle.Width(killsWidth).Render("Kills"),
+		headerStyle.Width(stolenWidth).Render("Stolen"),
+	}
+	if showDate {
+		headerCells = append(headerCells, headerStyle.Width(dateWidth).Render("Date"))
+	}
+	header := lipgloss.JoinHorizontal(lipgloss.Top, headerCells...)
 	tableContent.WriteString(header + "\n")
 
-	// Calculate the starting rank for the current page
-	startRank := (m.CurrentPage-1)*m.PageSize + 1
-
 	// 2. Data Rows
-	for i, score := range m.Scores {
-		rank := startRank + i
-		rowStyle := leaderboardRowStyle
+	for row, score := range m.Scores {
+		rank := score.Rank
+		rowStyle := m.theme.Bind(leaderboardRowStyle)
+		if m.playerName != "" && score.PlayerName == m.playerName {
+			rowStyle = rowStyle.Foreground(focusedColor)
+		}
+		if row == m.selectedRow {
+			rowStyle = rowStyle.Reverse(true)
+		}
 
 		// Format date
 		formattedDate := score.CreatedAt.Format("2006-01-02")
@@ -275,16 +505,26 @@ func (m LeaderboardModel) View() string {
 			formattedDate = "N/A"
 		}
 
+		// runs the home assist steered are marked
+		playerName := score.PlayerName
+		if score.Assisted {
+			playerName += " ⌂"
+		}
+
 		// Format Land Claimed to percentage (%.2f)
 		claimedLand := fmt.Sprintf("%.2f%%", score.ClaimedLand)
 
-		row := lipgloss.JoinHorizontal(lipgloss.Top,
+		rowCells := []string{
 			rowStyle.Width(rankWidth).Render(strconv.Itoa(rank)),
-			rowStyle.Width(nameWidth).Render(score.PlayerName),
+			rowStyle.Width(nameWidth).Render(playerName),
 			rowStyle.Width(estateWidth).Align(lipgloss.Right).Render(claimedLand),
 			rowStyle.Width(killsWidth).Align(lipgloss.Right).Render(strconv.Itoa(score.Kills)), // Adjusted width here
-			rowStyle.Width(dateWidth).Align(lipgloss.Left).Render(formattedDate),               // Adjusted width here
-		)
+			rowStyle.Width(stolenWidth).Align(lipgloss.Right).Render(strconv.Itoa(score.TilesStolen)),
+		}
+		if showDate {
+			rowCells = append(rowCells, rowStyle.Width(dateWidth).Align(lipgloss.Left).Render(formattedDate)) // Adjusted width here
+		}
+		row := lipgloss.JoinHorizontal(lipgloss.Top, rowCells...)
 
 		tableContent.WriteString(row + "\n")
 	}
@@ -298,41 +538,110 @@ func (m LeaderboardModel) View() string {
 	pageInfo := fmt.Sprintf("Page %d/%d (Total Scores: %d)", m.CurrentPage, totalPages, m.TotalScores)
 
 	// Navigation Arrows with subtle color change when available
-	arrowStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15")) // White/Bright
+	arrowStyle := m.theme.NewStyle().Foreground(lipgloss.Color("15")) // White/Bright
 
 	leftArrow := "←"
 	if m.CurrentPage == 1 {
-		leftArrow = lipgloss.NewStyle().Faint(true).Render("←")
+		leftArrow = m.theme.NewStyle().Faint(true).Render("←")
 	} else {
 		leftArrow = arrowStyle.Render("← (H)")
 	}
 
 	rightArrow := "→"
 	if m.CurrentPage == totalPages {
-		rightArrow = lipgloss.NewStyle().Faint(true).Render("→")
+		rightArrow = m.theme.NewStyle().Faint(true).Render("→")
 	} else {
 		rightArrow = arrowStyle.Render("(L) →")
 	}
 
 	paginationControls := lipgloss.JoinHorizontal(lipgloss.Center,
-		lipgloss.NewStyle().Width(8).Align(lipgloss.Right).Render(leftArrow),
-		lipgloss.NewStyle().Margin(0, 2).Render(pageInfo),
-		lipgloss.NewStyle().Width(8).Align(lipgloss.Left).Render(rightArrow),
+		m.theme.NewStyle().Width(8).Align(lipgloss.Right).Render(leftArrow),
+		m.theme.NewStyle().Margin(0, 2).Render(pageInfo),
+		m.theme.NewStyle().Width(8).Align(lipgloss.Left).Render(rightArrow),
 	)
 
 	// Removed Bold(true) from the title style
-	title := lipgloss.NewStyle().Padding(1, 0).Render("GLOBAL HIGH SCORES")
-	instruction := lipgloss.NewStyle().Faint(true).Margin(1, 0).Render("Press ESC or ENTER to return.")
+	title := m.theme.NewStyle().Padding(1, 0, 0, 0).Render("GLOBAL HIGH SCORES")
+
+	instructionText := "tab period · s sort · / search · esc back"
+	if m.playerName != "" {
+		instructionText = "tab period · s sort · / search · m my rank · esc back"
+	}
+	if m.searching {
+		instructionText = "enter to search · esc to clear"
+	}
+	instruction := m.theme.NewStyle().Faint(true).Render(instructionText)
 
 	finalContent := lipgloss.JoinVertical(lipgloss.Center,
 		title,
+		m.renderTabs(),
+		m.renderFilter(),
 		tableContent.String(),
-		lipgloss.NewStyle().Padding(1, 0).Render(paginationControls),
+		m.theme.NewStyle().Padding(1, 0).Render(paginationControls),
 		instruction,
 	)
 
-	return lipgloss.Place(m.ScreenWidth, m.ScreenHeight,
+	return m.theme.Renderer.Place(m.ScreenWidth, m.ScreenHeight,
+		lipgloss.Center, lipgloss.Center,
+		m.theme.NewStyle().Border(lipgloss.ThickBorder()).Render(finalContent),
+	)
+}
+
+func (m LeaderboardModel) renderTabs() string {
+	tabs := []string{}
+	for i, tab := range leaderboardWindows {
+		if i == m.windowIndex {
+			tabs = append(tabs, m.theme.NewStyle().Bold(true).Underline(true).Foreground(focusedColor).Render(tab.name))
+		} else {
+			tabs = append(tabs, m.theme.Bind(blurredStyle).Render(tab.name))
+		}
+	}
+
+	sortName := "land"
+	if m.Query.SortBy == game.ScoreSortKills {
+		sortName = "kills"
+	}
+	sortBy := m.theme.NewStyle().Faint(true).Render("by " + sortName)
+
+	return strings.Join(tabs, "  ") + "   " + sortBy
+}
+
+// renderFilter is the search box while typing, then the search in use or
+// whatever the last action has to say.
+func (m LeaderboardModel) renderFilter() string {
+	switch {
+	case m.searching:
+		return m.searchInput.View()
+	case m.notice != "":
+		return m.theme.Bind(errorStyle).Render(m.notice)
+	case m.Query.NameSearch != "":
+		return m.theme.NewStyle().Faint(true).Render(fmt.Sprintf("names with %q (/ to change)", m.Query.NameSearch))
+	case len(m.Scores) > 0:
+		return m.theme.NewStyle().Faint(true).Render("↑/↓ pick a run · enter shows its timeline")
+	}
+	return ""
+}
+
+// renderScoreTimeline is the timeline a score was saved with, drawn the way
+// the game over screen drew it.
+func (m LeaderboardModel) renderScoreTimeline() string {
+	score, summary := m.timelineScore, m.timeline
+
+	title := m.theme.NewStyle().Bold(true).Render(fmt.Sprintf("#%d %s", score.Rank, score.PlayerName))
+	stats := fmt.Sprintf("Land: %.2f%%  Kills: %d  Tiles Stolen: %d\nTime Alive: %s  Loops Closed: %d  Longest Tail: %d",
+		score.ClaimedLand, score.Kills, score.TilesStolen,
+		formatTicks(uint64(summary.TimeAlive/game.GameTickDuration)), summary.LoopsClosed, summary.LongestTail)
+	instruction := m.theme.NewStyle().Faint(true).Render("esc back to the scores")
+
+	content := lipgloss.JoinVertical(lipgloss.Center, title, stats, "", instruction)
+	// the border takes two lines, the timeline gets whatever else is left
+	timelineLines := m.ScreenHeight - lipgloss.Height(content) - 2 - 1
+	if timeline := renderTimeline(m.theme, summary, m.ScreenWidth-8, timelineLines); timeline != "" {
+		content = lipgloss.JoinVertical(lipgloss.Center, title, stats, "", timeline+"\n", instruction)
+	}
+
+	return m.theme.Renderer.Place(m.ScreenWidth, m.ScreenHeight,
 		lipgloss.Center, lipgloss.Center,
-		lipgloss.NewStyle().Border(lipgloss.ThickBorder()).Render(finalContent),
+		m.theme.NewStyle().Border(lipgloss.ThickBorder()).Padding(0, 2).Render(content),
 	)
 }
@@ -2,12 +2,12 @@ package ui
 
 import (
 	"fmt"
-	"sort"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Mshel/ouroboros/internal/game"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
@@ -16,10 +16,12 @@ import (
 
 // ShowGameOverMsg is the NEW message to signal the Controller to switch to the Game Over screen.
 type ShowGameOverMsg struct {
-	FinalEstate     float64
-	FinalKills      int
-	LeaderboardData []PlayerScore // Data ready to pass to GameOverModel
-	EstateInfo      map[*int]int  // Data ready to pass to GameOverModel
+	FinalEstate      float64
+	FinalKills       int
+	FinalTilesStolen int
+	LeaderboardData  []PlayerScore // Data ready to pass to GameOverModel
+	EstateInfo       map[*int]int  // Data ready to pass to GameOverModel
+	Summary          *game.LifeSummary
 }
 
 type QuitGameMsg struct{} // Used to signal the Controller to exit the game (used by anonymous leaderboard viewer)
@@ -37,9 +39,6 @@ var (
 				BorderForeground(lipgloss.Color("8")).
 				Padding(1, 2)
 
-	wallStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(strconv.Itoa(game.WallColor))).Render("▒")
-	voidStyle = lipgloss.NewStyle().Background(lipgloss.Color(strconv.Itoa(game.VoidColor))).Render(" ")
-
 	headRunes = map[game.Direction]rune{
 		{Dx: 0, Dy: -1}: '▲', // Up
 		{Dx: 0, Dy: 1}:  '▼', // Down
@@ -51,8 +50,20 @@ var (
 )
 
 const (
-	mapViewPercentage  = 0.70
-	statusPanelPadding = 4
+	mapViewPercentage   = 0.70
+	statusPanelPadding  = 4
+	compactLayoutWidth  = 90 // below this the status panel is hidden
+	minStatusPanelWidth = 32
+
+	maxNotifications      = 3
+	notificationTickLimit = 100
+
+	protectedBlinkTicks = 4
+
+	minimapHeadColor = 15
+	minimapMinLines  = 4
+
+	homeAssistLabel = "⌂ returning home"
 )
 
 type PlayerScore struct {
@@ -61,26 +72,54 @@ type PlayerScore struct {
 	Land  float64 // Stored as raw tile count
 }
 
+type statusNotification struct {
+	Text      string
+	Color     int
+	ExpiresAt int // tick count after which the notification is dropped
+}
+
 type GameViewModel struct {
 	tea.Model
 	TickCount       int
+	Notifications   []statusNotification
 	EstateInfo      map[*int]int
 	ScreenWidth     int
 	ScreenHeight    int
 	gameManager     *game.GameManager
 	UserSession     ssh.Session
+	theme           *Theme
+	keys            *KeyMap
+	help            help.Model
 	LeaderboardData []PlayerScore
+	mapCache        *mapViewCache
+	patterns        *rivalPatterns
+	ChatLog         []chatLine
+	Feed            []game.GameEvent
+	chatInput       textinput.Model
+	chatOpen        bool
+	chatError       string
 }
 
-func NewGameModel(gm *game.GameManager, session ssh.Session, screenWidth int, screenHeight int) GameViewModel {
+func NewGameModel(gm *game.GameManager, session ssh.Session, theme *Theme, keys *KeyMap, screenWidth int, screenHeight int) GameViewModel {
+	chatInput := newChatInput()
+	theme.bindTextInput(&chatInput)
+	keyHelp := help.New()
+	theme.bindHelp(&keyHelp)
+
 	return GameViewModel{
 		gameManager:     gm,
 		UserSession:     session,
+		theme:           theme,
+		keys:            keys,
+		help:            keyHelp,
 		TickCount:       0,
 		EstateInfo:      make(map[*int]int),
 		ScreenWidth:     screenWidth,
 		ScreenHeight:    screenHeight,
 		LeaderboardData: make([]PlayerScore, 0),
+		mapCache:        newMapViewCache(),
+		patterns:        newRivalPatterns(),
+		chatInput:       chatInput,
 	}
 }
 
@@ -103,20 +142,36 @@ func (m GameViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		currentPlayer := currentPlayerVal.(*game.Player)
 
+		if m.chatOpen {
+			return m.updateChat(msg, currentPlayer)
+		}
+
+		action, bound := m.keys.Action(msg)
+		if !bound {
+			return m, nil
+		}
+
 		var engineCommand game.Direction
-		switch msg.String() {
-		case "w", "up":
+		switch action {
+		case actionChat:
+			return m.openChat(), nil
+		case actionHome:
+			currentPlayer.SetHomeAssist(!currentPlayer.HomeAssist())
+			return m, nil
+		case actionUp:
 			engineCommand = game.Direction{Dx: 0, Dy: -1, PlayerColor: *currentPlayer.Color}
-		case "s", "down":
+		case actionDown:
 			engineCommand = game.Direction{Dx: 0, Dy: 1, PlayerColor: *currentPlayer.Color}
-		case "a", "left":
+		case actionLeft:
 			engineCommand = game.Direction{Dx: -1, Dy: 0, PlayerColor: *currentPlayer.Color}
-		case "d", "right":
+		case actionRight:
 			engineCommand = game.Direction{Dx: 1, Dy: 0, PlayerColor: *currentPlayer.Color}
-		case " ":
+		case actionBrake:
 			currentPlayer.ResetSpeed()
-		default:
-			return m, nil
+		}
+		// steering by hand takes over from the home assist
+		if action != actionBrake {
+			currentPlayer.SetHomeAssist(false)
 		}
 
 		oldSpeed := currentPlayer.Speed
@@ -141,9 +196,31 @@ func (m GameViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case tea.WindowSizeMsg:
+		m.ScreenWidth = msg.Width
+		m.ScreenHeight = msg.Height
+		return m, nil
+
 	case game.GameTickMsg:
 		m.TickCount++
 		m.LeaderboardData = m.calculateLeaderboard()
+		m.Notifications = m.activeNotifications()
+		m.Feed = m.recentFeed()
+		return m, m.listenForGameUpdates()
+
+	case game.TerritoryStolenMsg:
+		m.Notifications = append(m.activeNotifications(), statusNotification{
+			Text:      fmt.Sprintf("%s took %d tiles from you", msg.ThiefName, msg.Tiles),
+			Color:     msg.ThiefColor,
+			ExpiresAt: m.TickCount + notificationTickLimit,
+		})
+		if len(m.Notifications) > maxNotifications {
+			m.Notifications = m.Notifications[len(m.Notifications)-maxNotifications:]
+		}
+		return m, m.listenForGameUpdates()
+
+	case game.ChatMessage:
+		m.ChatLog = appendChatLine(m.ChatLog, chatLine{Name: msg.SenderName, Color: msg.SenderColor, Text: msg.Text})
 		return m, m.listenForGameUpdates()
 
 	case game.ClaimedEstateMsg:
@@ -159,10 +236,12 @@ func (m GameViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.gameManager.SessionsToPlayers.Delete(m.UserSession)
 				return m, func() tea.Msg {
 					return ShowGameOverMsg{
-						FinalEstate:     (msg.FinalClaimedEstate * 100) / float64(game.MapColCount*game.MapRowCount),
-						FinalKills:      msg.FinalKills,
-						LeaderboardData: m.LeaderboardData,
-						EstateInfo:      m.EstateInfo,
+						FinalEstate:      game.LandPercent(msg.FinalClaimedEstate),
+						FinalKills:       msg.FinalKills,
+						FinalTilesStolen: msg.FinalTilesStolen,
+						LeaderboardData:  m.LeaderboardData,
+						EstateInfo:       m.EstateInfo,
+						Summary:          msg.Summary,
 					}
 				}
 			}
@@ -174,24 +253,29 @@ func (m GameViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m GameViewModel) activeNotifications() []statusNotification {
+	active := []statusNotification{}
+	for _, notification := range m.Notifications {
+		if notification.ExpiresAt > m.TickCount {
+			active = append(active, notification)
+		}
+	}
+	return active
+}
+
 func (m GameViewModel) calculateLeaderboard() []PlayerScore {
-	var playerScores []PlayerScore
+	standings := m.gameManager.GetTickSnapshot().Standings
+	playerScores := make([]PlayerScore, 0, min(5, len(standings)))
 
-	m.gameManager.Players.Range(func(key, value interface{}) bool {
-		player, _ := value.(*game.Player)
+	for _, standing := range standings[:min(5, len(standings))] {
 		playerScores = append(playerScores, PlayerScore{
-			Name:  player.Name,
-			Color: *player.Color,
-			Land:  player.GetConsolidateTiles(),
+			Name:  standing.Name,
+			Color: standing.Color,
+			Land:  float64(standing.Land),
 		})
-		return true
-	})
-
-	sort.Slice(playerScores, func(i, j int) bool {
-		return playerScores[i].Land > playerScores[j].Land
-	})
+	}
 
-	return playerScores[:min(5, len(playerScores))]
+	return playerScores
 }
 
 func (m GameViewModel) View() string {
@@ -199,15 +283,24 @@ func (m GameViewModel) View() string {
 	currentPlayerVal, ok := m.gameManager.SessionsToPlayers.Load(m.UserSession)
 	if !ok || currentPlayerVal == nil {
 		if m.UserSession != nil {
-			return lipgloss.Place(m.ScreenWidth, m.ScreenHeight, lipgloss.Center, lipgloss.Center, "Game Over... Switching screen...")
+			return m.theme.Renderer.Place(m.ScreenWidth, m.ScreenHeight, lipgloss.Center, lipgloss.Center, "Game Over... Switching screen...")
 		}
-		return lipgloss.Place(m.ScreenWidth, m.ScreenHeight, lipgloss.Center, lipgloss.Center, "Waiting for game manager...")
+		return m.theme.Renderer.Place(m.ScreenWidth, m.ScreenHeight, lipgloss.Center, lipgloss.Center, "Waiting for game manager...")
 	}
 
 	currentPlayer := currentPlayerVal.(*game.Player)
 
+	if m.ScreenWidth < compactLayoutWidth {
+		return m.compactView(currentPlayer)
+	}
+
 	mapWidth := int(float64(m.ScreenWidth) * mapViewPercentage)
 	statusPanelWidth := m.ScreenWidth - mapWidth - statusPanelPadding
+	// the map gives up columns before the panel's headers start wrapping
+	if statusPanelWidth < minStatusPanelWidth {
+		statusPanelWidth = minStatusPanelWidth
+		mapWidth = m.ScreenWidth - statusPanelWidth - statusPanelPadding
+	}
 
 	const mapHorizontalOverhead = 2
 	mapContentWidth := mapWidth - mapHorizontalOverhead
@@ -230,11 +323,47 @@ func (m GameViewModel) View() string {
 
 	mapContent := m.renderMap(currentPlayer, mapContentWidth, mapContentHeight)
 
-	statusContent := m.renderStatusPanel(currentPlayer, statusPanelWidth, statusContentHeight)
+	// the panel's padding takes 2 columns on either side
+	const statusPanelHorizontalOverhead = 4
+	statusContentWidth := max(0, statusPanelWidth-statusPanelHorizontalOverhead)
 
+	statusContent := m.renderStatusPanel(currentPlayer, statusContentWidth, statusContentHeight)
+
+	// heights leave out the borders so both panels fit the terminal exactly
 	return lipgloss.JoinHorizontal(lipgloss.Top,
-		mapViewStyle.Width(mapWidth).Height(m.ScreenHeight).Render(mapContent),
-		statusPanelStyle.Width(statusPanelWidth).Height(m.ScreenHeight).Render(statusContent),
+		m.theme.Bind(mapViewStyle).Width(mapWidth).Height(m.ScreenHeight-mapVerticalOverhead).Render(mapContent),
+		m.theme.Bind(statusPanelStyle).Width(statusPanelWidth).Height(m.ScreenHeight-mapVerticalOverhead).Render(statusContent),
+	)
+}
+
+// compactView drops the status panel on narrow terminals, one line under the
+// map keeps the essentials, or the chat input while it is open.
+func (m GameViewModel) compactView(currentPlayer *game.Player) string {
+	const mapHorizontalOverhead = 2
+	const mapVerticalOverhead = 2 + 1
+
+	mapContentWidth := max(0, m.ScreenWidth-mapHorizontalOverhead)
+	mapContentHeight := max(0, m.ScreenHeight-mapVerticalOverhead)
+	mapContent := m.renderMap(currentPlayer, mapContentWidth, mapContentHeight)
+
+	claimedLand := float64(m.gameManager.Territory.Get(*currentPlayer.Color))
+	statusLine := fmt.Sprintf("Land: %.2f %%  Kills: %d  Speed: %d  ",
+		game.LandPercent(claimedLand), currentPlayer.Kills, currentPlayer.Speed)
+	if currentPlayer.HomeAssist() {
+		statusLine = homeAssistLabel + "  " + statusLine
+	}
+	keyHelp := m.help
+	keyHelp.Width = max(0, m.ScreenWidth-lipgloss.Width(statusLine))
+	statusLine += keyHelp.ShortHelpView(m.keys.ShortHelp())
+	if m.chatOpen {
+		input := m.chatInput
+		input.Width = max(1, m.ScreenWidth-len(input.Prompt)-1)
+		statusLine = input.View()
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.theme.Bind(mapViewStyle).Width(mapContentWidth).Height(mapContentHeight).Render(mapContent),
+		m.theme.NewStyle().MaxWidth(m.ScreenWidth).Render(statusLine),
 	)
 }
 
@@ -255,8 +384,6 @@ func (m GameViewModel) renderMap(currentPlayer *game.Player, width int, height i
 		startCol = max(0, game.MapColCount-effectiveViewportW)
 	}
 
-	endCol := min(game.MapColCount, startCol+effectiveViewportW)
-
 	desiredStartRow := centerTileY - effectiveViewportH/2
 
 	startRow := max(0, desiredStartRow)
@@ -265,27 +392,50 @@ func (m GameViewModel) renderMap(currentPlayer *game.Player, width int, height i
 		startRow = max(0, game.MapRowCount-effectiveViewportH)
 	}
 
-	endRow := min(game.MapRowCount, startRow+effectiveViewportH)
+	// a wrapping world has no edge to stop at, the head always stays centered
+	if game.WrapAround {
+		startCol = desiredStartCol
+		startRow = desiredStartRow
+	}
 
-	mapSegment := m.gameManager.GetMapCopy(startRow, endRow, startCol, endCol)
+	endCol := startCol + effectiveViewportW
+	endRow := startRow + effectiveViewportH
+	if !game.WrapAround {
+		endCol = min(game.MapColCount, endCol)
+		endRow = min(game.MapRowCount, endRow)
+	}
+
+	mapSegment := m.mapCache.window(m.gameManager, startRow, endRow, startCol, endCol)
 	if len(mapSegment) == 0 {
 		return "Error rendering map segment."
 	}
 
 	viewHeight := len(mapSegment)
 	viewWidth := len(mapSegment[0])
+	blinkOn := (m.gameManager.GetTickSnapshot().Tick/protectedBlinkTicks)%2 == 0
+
+	ownColor := *currentPlayer.Color
+	if m.theme.Patterns() {
+		m.patterns.update(visibleRivals(mapSegment, ownColor))
+	}
+	radar := m.radarIndicators(currentPlayer, startRow, startCol, viewHeight, viewWidth)
 
 	for row := 0; row < viewHeight; row++ {
 		for col := 0; col < viewWidth; col++ {
 			globalRow := startRow + row
 			globalCol := startCol + col
 
-			if globalRow <= 0 || globalRow >= game.MapRowCount-1 || globalCol <= 0 || globalCol >= game.MapColCount-1 {
-				sb.WriteString(wallStyle)
+			tile := mapSegment[row][col]
+
+			if indicator, ok := radar[[2]int{row, col}]; ok {
+				sb.WriteString(indicator)
 				continue
 			}
 
-			tile := mapSegment[row][col]
+			if game.IsWall(globalRow, globalCol) {
+				sb.WriteString(m.theme.wall)
+				continue
+			}
 			var tileOwner *game.Player
 			if tile.OwnerColor != nil {
 				tileOwnerAny, ownerExists := m.gameManager.Players.Load(*tile.OwnerColor)
@@ -295,144 +445,281 @@ func (m GameViewModel) renderMap(currentPlayer *game.Player, width int, height i
 			}
 
 			if tileOwner != nil && tile.X == tileOwner.Location.X && tile.Y == tileOwner.Location.Y {
-				colorStyle := lipgloss.NewStyle().Background(lipgloss.Color(strconv.Itoa(game.VoidColor))).
-					Foreground(lipgloss.Color(strconv.Itoa(*tileOwner.Color))).Bold(true)
+				colorStyle := m.theme.NewStyle().Background(m.theme.Color(game.VoidColor)).
+					Foreground(m.theme.Color(*tileOwner.Color)).Bold(true)
+				// spawn protected heads blink
+				if tileOwner.IsProtected() && blinkOn {
+					colorStyle = colorStyle.Reverse(true)
+				}
 				sb.WriteString(colorStyle.Render(string(headRunes[game.Direction{Dx: tileOwner.CurrentDirection.Dx, Dy: tileOwner.CurrentDirection.Dy}])))
 				continue
 			}
 
 			if tile.OwnerColor != nil {
-				colorStyle := lipgloss.NewStyle().Background(lipgloss.Color(strconv.Itoa(game.VoidColor))).
-					Foreground(lipgloss.Color(strconv.Itoa(*tile.OwnerColor)))
+				colorStyle := m.theme.NewStyle().Background(m.theme.Color(game.VoidColor)).
+					Foreground(m.theme.Color(*tile.OwnerColor))
 
 				if tile.IsTail {
-					hasUp, hasDown, hasLeft, hasRight := false, false, false, false
-
-					if row-1 >= 0 {
-						n := mapSegment[row-1][col]
-						if n.IsTail && n.OwnerColor != nil && tile.OwnerColor != nil && *n.OwnerColor == *tile.OwnerColor {
-							hasUp = true
-						}
-					}
-					if row+1 < viewHeight {
-						n := mapSegment[row+1][col]
-						if n.IsTail && n.OwnerColor != nil && tile.OwnerColor != nil && *n.OwnerColor == *tile.OwnerColor {
-							hasDown = true
-						}
-					}
-					if col-1 >= 0 {
-						n := mapSegment[row][col-1]
-						if n.IsTail && n.OwnerColor != nil && tile.OwnerColor != nil && *n.OwnerColor == *tile.OwnerColor {
-							hasLeft = true
-						}
-					}
-					if col+1 < viewWidth {
-						n := mapSegment[row][col+1]
-						if n.IsTail && n.OwnerColor != nil && tile.OwnerColor != nil && *n.OwnerColor == *tile.OwnerColor {
-							hasRight = true
-						}
-					}
-
-					var tailRune string
-
-					switch {
-					case (hasUp && hasDown) || (hasUp && !hasLeft && !hasRight && !hasDown) || (hasDown && !hasLeft && !hasRight && !hasUp):
-						tailRune = "│"
-					case (hasLeft && hasRight) || (hasLeft && !hasUp && !hasDown && !hasRight) || (hasRight && !hasUp && !hasDown && !hasLeft):
-						tailRune = "─"
-					case hasUp && hasRight:
-						tailRune = "└"
-					case hasUp && hasLeft:
-						tailRune = "┘"
-					case hasDown && hasRight:
-						tailRune = "┌"
-					case hasDown && hasLeft:
-						tailRune = "┐"
-					default:
-						tailRune = "•"
+					style := tailStyles[0]
+					if pattern, ok := m.rivalPattern(*tile.OwnerColor, ownColor); ok {
+						style = rivalTailStyle(pattern)
 					}
 
-					sb.WriteString(colorStyle.Render(tailRune))
+					sb.WriteString(colorStyle.Render(tailRune(mapSegment, row, col, style)))
 				} else {
-					sb.WriteString(colorStyle.Render(claimedEstateRune))
+					sb.WriteString(colorStyle.Render(m.landRune(mapSegment, row, col, ownColor)))
 				}
+			} else if tile.Terrain == game.TerrainNoBuild {
+				sb.WriteString(m.theme.noBuild)
 			} else {
-				sb.WriteString(voidStyle)
+				sb.WriteString(m.theme.void)
 			}
 		}
 		sb.WriteString("\n")
 	}
 
-	renderedMap := sb.String()
+	renderedMap := strings.TrimSuffix(sb.String(), "\n")
 
-	paddedMap := lipgloss.NewStyle().Width(width).Height(height).Render(renderedMap)
+	paddedMap := m.theme.NewStyle().Width(width).Height(height).Render(renderedMap)
 
 	return paddedMap
 }
 
+// rivalPattern is the pattern a rival on screen is drawn with, when patterns are on.
+func (m GameViewModel) rivalPattern(color int, ownColor int) (int, bool) {
+	if !m.theme.Patterns() || color == ownColor {
+		return 0, false
+	}
+	return m.patterns.pattern(color)
+}
+
+// landRune picks the rune of a land tile. With patterns on rivals get their own
+// and the edge of your land is drawn solid so it stands out from everybody's.
+func (m GameViewModel) landRune(mapSegment [][]game.Tile, row int, col int, ownColor int) string {
+	tileColor := *mapSegment[row][col].OwnerColor
+	if pattern, ok := m.rivalPattern(tileColor, ownColor); ok {
+		return rivalGlyph(pattern)
+	}
+	if !m.theme.Patterns() || tileColor != ownColor {
+		return m.theme.Glyph(tileColor)
+	}
+
+	for _, offset := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+		neighborRow, neighborCol := row+offset[0], col+offset[1]
+		if neighborRow < 0 || neighborRow >= len(mapSegment) || neighborCol < 0 || neighborCol >= len(mapSegment[neighborRow]) {
+			continue
+		}
+		neighbor := mapSegment[neighborRow][neighborCol]
+		if neighbor.IsTail || neighbor.OwnerColor == nil || *neighbor.OwnerColor != ownColor {
+			return ownBorderRune
+		}
+	}
+	return claimedEstateRune
+}
+
+// tailRune joins a tail tile to the tail tiles of the same color next to it.
+func tailRune(mapSegment [][]game.Tile, row int, col int, style tailStyle) string {
+	tile := mapSegment[row][col]
+	viewHeight, viewWidth := len(mapSegment), len(mapSegment[row])
+	hasUp, hasDown, hasLeft, hasRight := false, false, false, false
+
+	if row-1 >= 0 {
+		n := mapSegment[row-1][col]
+		if n.IsTail && n.OwnerColor != nil && tile.OwnerColor != nil && *n.OwnerColor == *tile.OwnerColor {
+			hasUp = true
+		}
+	}
+	if row+1 < viewHeight {
+		n := mapSegment[row+1][col]
+		if n.IsTail && n.OwnerColor != nil && tile.OwnerColor != nil && *n.OwnerColor == *tile.OwnerColor {
+			hasDown = true
+		}
+	}
+	if col-1 >= 0 {
+		n := mapSegment[row][col-1]
+		if n.IsTail && n.OwnerColor != nil && tile.OwnerColor != nil && *n.OwnerColor == *tile.OwnerColor {
+			hasLeft = true
+		}
+	}
+	if col+1 < viewWidth {
+		n := mapSegment[row][col+1]
+		if n.IsTail && n.OwnerColor != nil && tile.OwnerColor != nil && *n.OwnerColor == *tile.OwnerColor {
+			hasRight = true
+		}
+	}
+
+	switch {
+	case (hasUp && hasDown) || (hasUp && !hasLeft && !hasRight && !hasDown) || (hasDown && !hasLeft && !hasRight && !hasUp):
+		return style.vertical
+	case (hasLeft && hasRight) || (hasLeft && !hasUp && !hasDown && !hasRight) || (hasRight && !hasUp && !hasDown && !hasLeft):
+		return style.horizontal
+	case hasUp && hasRight:
+		return style.upRight
+	case hasUp && hasLeft:
+		return style.upLeft
+	case hasDown && hasRight:
+		return style.downRight
+	case hasDown && hasLeft:
+		return style.downLeft
+	default:
+		return style.single
+	}
+}
+
+func visibleRivals(mapSegment [][]game.Tile, ownColor int) []int {
+	seen := make(map[int]bool)
+	rivals := []int{}
+	for _, tiles := range mapSegment {
+		for _, tile := range tiles {
+			if tile.OwnerColor == nil || *tile.OwnerColor == ownColor || seen[*tile.OwnerColor] {
+				continue
+			}
+			seen[*tile.OwnerColor] = true
+			rivals = append(rivals, *tile.OwnerColor)
+		}
+	}
+	return rivals
+}
+
 func (m GameViewModel) renderStatusPanel(currentPlayer *game.Player, width int, height int) string {
 
 	var statusContent strings.Builder
 
 	// Count of all static lines (excluding the leaderboard list)
-	// Player Stats: 5 lines + 1 blank = 6
+	// Player Stats: 6 lines + 1 blank = 7
+	// Map: 1 line
 	// Leaderboard Header: 3 lines
-	// Controls: 5 lines
-	const totalStaticLines = 6 + 3 + 5
+	const totalStaticLines = 7 + 1 + 3
+
+	controls := m.renderControls(width)
 
 	// Lines available for leaderboard items
-	linesForLeaderboard := height - totalStaticLines
+	linesForLeaderboard := height - totalStaticLines - lipgloss.Height(controls) - len(m.Notifications) - m.chatLineCount() - m.feedLineCount()
 
 	// --- Render Top Static Content (Player Stats) ---
 	// (This section remains unchanged from your original code)
-	statusContent.WriteString(lipgloss.NewStyle().Bold(true).Render("--- Player Stats ---\n"))
-	claimedLand := currentPlayer.GetConsolidateTiles()
-	statusContent.WriteString(fmt.Sprintf("Direction: %c\n", headRunes[game.Direction{Dx: currentPlayer.CurrentDirection.Dx, Dy: currentPlayer.CurrentDirection.Dy}]))
+	statusContent.WriteString(m.theme.NewStyle().Bold(true).Render("--- Player Stats ---\n"))
+	claimedLand := float64(m.gameManager.Territory.Get(*currentPlayer.Color))
+	statusContent.WriteString(fmt.Sprintf("Direction: %c", headRunes[game.Direction{Dx: currentPlayer.CurrentDirection.Dx, Dy: currentPlayer.CurrentDirection.Dy}]))
+	if currentPlayer.HomeAssist() {
+		statusContent.WriteString("  " + m.theme.NewStyle().Bold(true).Foreground(focusedColor).Render(homeAssistLabel))
+	}
+	statusContent.WriteString("\n")
 	statusContent.WriteString(fmt.Sprintf("Speed: %d \n", currentPlayer.Speed))
 
 	statusContent.WriteString(fmt.Sprintf("Kills: %d\n", currentPlayer.Kills))
-	statusContent.WriteString(fmt.Sprintf("Claimed: %.2f %% of land\n", claimedLand*100/float64(game.MapColCount*game.MapColCount)))
+	statusContent.WriteString(fmt.Sprintf("Claimed: %.2f %% of land\n", game.LandPercent(claimedLand)))
+	statusContent.WriteString(fmt.Sprintf("Stolen: %d tiles\n", currentPlayer.TilesStolen))
 	statusContent.WriteString("\n")
 
-	botCount := 0
-	realPlayerCount := 0
-	m.gameManager.Players.Range(func(key, value interface{}) bool {
-		player, _ := value.(*game.Player)
-		if player.BotStrategy != nil {
-			botCount += 1
-		} else {
-			realPlayerCount += 1
-		}
-		return true
-	})
-	statusContent.WriteString(fmt.Sprintf("Players Count: %d\n", realPlayerCount))
-	statusContent.WriteString(fmt.Sprintf("Bots count: %d\n", botCount))
-	statusContent.WriteString(lipgloss.NewStyle().Bold(true).Render("--- Leaderboard(TOP 5) ---") + "\n")
+	for _, notification := range m.Notifications {
+		notificationStyle := m.theme.NewStyle().Foreground(m.theme.Color(notification.Color))
+		statusContent.WriteString(notificationStyle.Render("! "+notification.Text) + "\n")
+	}
+
+	mapInfo := m.gameManager.MapInfo()
+	if mapInfo.Generated {
+		statusContent.WriteString(fmt.Sprintf("Map: %s (seed %d)\n", mapInfo.Name, mapInfo.Seed))
+	} else {
+		statusContent.WriteString(fmt.Sprintf("Map: %s\n", mapInfo.Name))
+	}
+
+	snapshot := m.gameManager.GetTickSnapshot()
+	statusContent.WriteString(fmt.Sprintf("Players Count: %d\n", snapshot.HumanCount))
+	statusContent.WriteString(fmt.Sprintf("Bots count: %d\n", snapshot.BotCount))
+	statusContent.WriteString(m.theme.NewStyle().Bold(true).Render("--- Leaderboard(TOP 5) ---") + "\n")
 
 	leaderboardItemsToRender := min(5, len(m.LeaderboardData))
 	leaderboardItemsToRender = min(leaderboardItemsToRender, linesForLeaderboard)
 
 	for i := 0; i < leaderboardItemsToRender; i++ {
 		score := m.LeaderboardData[i]
-		colorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(strconv.Itoa(score.Color)))
-		statusContent.WriteString(fmt.Sprintf("%d. %s%s: %.2f %%\n", i+1, colorStyle.Render("● "), score.Name,
-			score.Land*100/float64(game.MapColCount*game.MapColCount)))
+		colorStyle := m.theme.NewStyle().Foreground(m.theme.Color(score.Color))
+		marker := m.theme.Marker(score.Color)
+		if pattern, ok := m.rivalPattern(score.Color, *currentPlayer.Color); ok {
+			marker = rivalGlyph(pattern)
+		} else if m.theme.Patterns() && score.Color == *currentPlayer.Color {
+			marker = ownBorderRune
+		}
+		statusContent.WriteString(fmt.Sprintf("%d. %s%s: %.2f %%\n", i+1, colorStyle.Render(marker+" "), score.Name,
+			game.LandPercent(score.Land)))
 	}
 
+	linesLeft := linesForLeaderboard - leaderboardItemsToRender
 	if leaderboardItemsToRender < len(m.LeaderboardData) && linesForLeaderboard > 0 {
 		if linesForLeaderboard > leaderboardItemsToRender {
 			statusContent.WriteString("...\n")
+			linesLeft--
 		}
 	}
 
-	statusContent.WriteString("\n" + lipgloss.NewStyle().Bold(true).Render("--- Controls ---\n"))
-	statusContent.WriteString("WASD / Arrows: Move\n")
-	statusContent.WriteString("Q / Ctrl+C: Quit Game\n")
-	statusContent.WriteString("\n" + lipgloss.NewStyle().Faint(true).Render("Press ESC/Enter to Exit"))
+	statusContent.WriteString(m.renderKillFeed(width))
+	statusContent.WriteString(m.renderChat(width))
+
+	// whatever room the panel has left goes to the minimap, blank line and header included
+	if minimapLines := linesLeft - 2; minimapLines >= minimapMinLines && snapshot.Minimap != nil {
+		statusContent.WriteString("\n" + m.theme.NewStyle().Bold(true).Render("--- Minimap ---") + "\n")
+		statusContent.WriteString(m.renderMinimap(snapshot.Minimap, currentPlayer, width, minimapLines))
+	}
+
+	statusContent.WriteString(controls)
 
 	return statusContent.String()
 }
 
+// renderControls lists the session's bindings, one per line.
+func (m GameViewModel) renderControls(width int) string {
+	var sb strings.Builder
+	lineStyle := m.theme.NewStyle().MaxWidth(width)
+
+	sb.WriteString("\n" + m.theme.NewStyle().Bold(true).Render("--- Controls ---"))
+	for _, binding := range m.keys.HelpBindings() {
+		sb.WriteString("\n" + lineStyle.Render(binding.Help().Key+": "+binding.Help().Desc))
+	}
+
+	return sb.String()
+}
+
+// renderMinimap draws the world in half blocks, two minimap rows per line. Your
+// land shows in your color wherever you own a bit of a cell, your head in white.
+func (m GameViewModel) renderMinimap(minimap *game.Minimap, currentPlayer *game.Player, width int, lines int) string {
+	cols := min(width, minimap.Cols)
+	rows := min(2*lines, max(2, cols*minimap.Rows/minimap.Cols))
+	rows -= rows % 2
+	// short panels shrink the width too so the world keeps its shape
+	cols = min(cols, rows*minimap.Cols/minimap.Rows)
+	if cols <= 0 || rows <= 0 {
+		return ""
+	}
+
+	headRow, headCol := minimap.CellOf(currentPlayer.Location)
+	headRow, headCol = headRow*rows/minimap.Rows, headCol*cols/minimap.Cols
+
+	cellColor := func(row int, col int) int {
+		if row == headRow && col == headCol {
+			return minimapHeadColor
+		}
+		minimapRow, minimapCol := row*minimap.Rows/rows, col*minimap.Cols/cols
+		if minimap.HasColor(minimapRow, minimapCol, *currentPlayer.Color) {
+			return *currentPlayer.Color
+		}
+		return minimap.Color(minimapRow, minimapCol)
+	}
+
+	var sb strings.Builder
+	for row := 0; row < rows; row += 2 {
+		for col := range cols {
+			cellStyle := m.theme.NewStyle().
+				Foreground(m.theme.Color(cellColor(row, col))).
+				Background(m.theme.Color(cellColor(row+1, col)))
+			sb.WriteString(cellStyle.Render("▀"))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
 func (m GameViewModel) listenForGameUpdates() tea.Cmd {
 	if m.UserSession == nil {
 		return tea.Tick(game.GameTickDuration, func(t time.Time) tea.Msg {
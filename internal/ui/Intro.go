@@ -9,35 +9,31 @@ import (
 
 // IntroModel holds the state for the main menu.
 type IntroModel struct {
-	selected int // 0: Start Registration, 1: View Leaderboard
+	selected int // 0: Start Registration, 1: View Leaderboard, 2: How to Play
+	theme    *Theme
 	width    int
 	height   int
 }
 
-func NewIntroModel(w, h int) IntroModel {
-	return IntroModel{selected: 0, width: w, height: h}
+func NewIntroModel(theme *Theme, w, h int) IntroModel {
+	return IntroModel{selected: 0, theme: theme, width: w, height: h}
 }
 
 func (m IntroModel) Init() tea.Cmd { return nil }
 
 func (m IntroModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "left", "h":
-			// Select the previous button: 0 -> 1, 1 -> 0
-			if m.selected == 0 {
-				m.selected = 1
-			} else {
-				m.selected = 0
-			}
+			// Select the previous button, wrapping around
+			m.selected = (m.selected + len(introButtons) - 1) % len(introButtons)
 		case "right", "l":
-			// Select the next button: 0 -> 1, 1 -> 0
-			if m.selected == 0 {
-				m.selected = 1
-			} else {
-				m.selected = 0
-			}
+			// Select the next button, wrapping around
+			m.selected = (m.selected + 1) % len(introButtons)
 		case "enter":
 			// Submit the selected option
 			return m, func() tea.Msg { return IntroSubmitMsg(m.selected) }
@@ -72,6 +68,11 @@ var ouroborosAscii = `
                  ████████████                                    ███████████                
 `
 
+const introButtonsHeight = 6
+
+// introButtons are in IntroSubmitMsg order.
+var introButtons = []string{"Start Registration", "View Leaderboard", "How to Play"}
+
 var (
 	asciiStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("87"))
@@ -90,26 +91,41 @@ var (
 func (m IntroModel) View() string {
 	var sb strings.Builder
 	sb.WriteString("\n")
-	sb.WriteString(asciiStyle.Render(ouroborosAscii))
+	// the art needs a big terminal, small ones only get the name
+	if lipgloss.Width(ouroborosAscii) <= m.width && lipgloss.Height(ouroborosAscii)+introButtonsHeight <= m.height {
+		sb.WriteString(m.theme.Bind(asciiStyle).Render(ouroborosAscii))
+	} else {
+		sb.WriteString(m.theme.Bind(asciiStyle).Bold(true).Render("O U R O B O R O S"))
+	}
 	sb.WriteString("\n")
 
-	register := introButtonStyle.Render("Start Registration")
-	leaderboard := introButtonStyle.Render("View Leaderboard")
+	button := m.theme.Bind(introButtonStyle)
+	selectedButton := m.theme.Bind(introSelectedButtonStyle)
 
-	// Apply selected style based on m.selected
-	if m.selected == 0 {
-		register = introSelectedButtonStyle.Render("Start Registration")
-	} else {
-		leaderboard = introSelectedButtonStyle.Render("View Leaderboard")
+	buttons := m.renderButtons(button, selectedButton, lipgloss.JoinHorizontal)
+	// narrow terminals get them stacked, without the margins between them
+	if lipgloss.Width(buttons) > m.width {
+		buttons = m.renderButtons(button.Margin(0, 2), selectedButton.Margin(0, 2), lipgloss.JoinVertical)
 	}
 
-	buttons := lipgloss.JoinHorizontal(lipgloss.Center, register, leaderboard)
-
 	content := lipgloss.JoinVertical(lipgloss.Center, sb.String(), buttons)
 
 	// Center the entire view within the terminal
-	return lipgloss.Place(m.width, m.height,
+	return m.theme.Renderer.Place(m.width, m.height,
 		lipgloss.Center, lipgloss.Center,
 		content,
 	)
 }
+
+func (m IntroModel) renderButtons(button lipgloss.Style, selectedButton lipgloss.Style, join func(lipgloss.Position, ...string) string) string {
+	renderedButtons := []string{}
+	for i, label := range introButtons {
+		// Apply selected style based on m.selected
+		if i == m.selected {
+			renderedButtons = append(renderedButtons, selectedButton.Render(label))
+		} else {
+			renderedButtons = append(renderedButtons, button.Render(label))
+		}
+	}
+	return join(lipgloss.Center, renderedButtons...)
+}
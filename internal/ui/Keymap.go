@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyAction is something a key can be bound to in game.
+type keyAction int
+
+const (
+	actionUp keyAction = iota
+	actionLeft
+	actionDown
+	actionRight
+	actionBrake
+	actionChat
+	actionHome
+)
+
+var (
+	keyActionNames = []string{"up", "left", "down", "right", "brake", "chat", "home"}
+	keyActionHelp  = []string{"move up", "move left", "move down", "move right", "slow down", "chat", "return home"}
+)
+
+const customKeymap = "custom"
+
+type keymapPreset struct {
+	name string
+	keys [][]string
+}
+
+// keymapPresets are the layouts offered on the setup screen, classic is what
+// the game always had. Keys are listed per action, in keyAction order.
+var keymapPresets = []keymapPreset{
+	{"classic", [][]string{{"w", "up"}, {"a", "left"}, {"s", "down"}, {"d", "right"}, {" "}, {"enter"}, {"r"}}},
+	{"wasd", [][]string{{"w"}, {"a"}, {"s"}, {"d"}, {" "}, {"enter"}, {"r"}}},
+	{"arrows", [][]string{{"up"}, {"left"}, {"down"}, {"right"}, {" "}, {"enter"}, {"r"}}},
+	{"vim", [][]string{{"k"}, {"h"}, {"j"}, {"l"}, {" "}, {"enter"}, {"r"}}},
+}
+
+// reservedKeys can't be rebound, ctrl+c always quits and esc backs out of menus.
+var reservedKeys = []string{"ctrl+c", "esc"}
+
+var quitBinding = key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit"))
+
+// KeyMap is the in game controls of a session. It starts as one of the presets
+// and turns custom once a key is rebound on the settings screen.
+type KeyMap struct {
+	Preset   string
+	bindings []key.Binding
+}
+
+func NewKeyMap() *KeyMap {
+	km := &KeyMap{}
+	km.usePreset(0)
+	return km
+}
+
+func (km *KeyMap) usePreset(index int) {
+	km.Preset = keymapPresets[index].name
+	km.setKeys(keymapPresets[index].keys)
+}
+
+func (km *KeyMap) setKeys(keys [][]string) {
+	km.bindings = make([]key.Binding, len(keyActionNames))
+	for action := range km.bindings {
+		km.bindings[action] = key.NewBinding(
+			key.WithKeys(keys[action]...),
+			key.WithHelp(keyListName(keys[action]), keyActionHelp[action]),
+		)
+	}
+}
+
+func (km *KeyMap) keys() [][]string {
+	keys := make([][]string, len(km.bindings))
+	for action, binding := range km.bindings {
+		keys[action] = slices.Clone(binding.Keys())
+	}
+	return keys
+}
+
+// cyclePreset moves to the next or previous preset, a custom map starts over from the first.
+func (km *KeyMap) cyclePreset(step int) {
+	index := slices.IndexFunc(keymapPresets, func(preset keymapPreset) bool {
+		return preset.name == km.Preset
+	})
+	count := len(keymapPresets)
+	switch {
+	case index < 0 && step > 0:
+		index = 0
+	case index < 0:
+		index = count - 1
+	default:
+		index = ((index+step)%count + count) % count
+	}
+	km.usePreset(index)
+}
+
+// rebind makes pressed the only key of an action, keys taken by another
+// action or reserved are refused.
+func (km *KeyMap) rebind(action keyAction, pressed string) error {
+	if slices.Contains(reservedKeys, pressed) {
+		return fmt.Errorf("%s can't be rebound", keyName(pressed))
+	}
+	for other, binding := range km.bindings {
+		if keyAction(other) != action && slices.Contains(binding.Keys(), pressed) {
+			return fmt.Errorf("%s is already bound to %s", keyName(pressed), keyActionNames[other])
+		}
+	}
+
+	keys := km.keys()
+	keys[action] = []string{pressed}
+	km.Preset = customKeymap
+	km.setKeys(keys)
+	return nil
+}
+
+// Action is what a key press does in game, if anything.
+func (km *KeyMap) Action(msg tea.KeyMsg) (keyAction, bool) {
+	for action, binding := range km.bindings {
+		if key.Matches(msg, binding) {
+			return keyAction(action), true
+		}
+	}
+	return 0, false
+}
+
+// Encode is how the keymap is saved with the profile: a preset by its name,
+// a custom map as the keys of every action.
+func (km *KeyMap) Encode() string {
+	if km.Preset != customKeymap {
+		return km.Preset
+	}
+
+	saved := make(map[string][]string, len(km.bindings))
+	for action, keys := range km.keys() {
+		saved[keyActionNames[action]] = keys
+	}
+	encoded, _ := json.Marshal(saved)
+	return string(encoded)
+}
+
+// Load brings back a saved keymap, anything it can't read leaves the current one.
+func (km *KeyMap) Load(saved string) {
+	for index, preset := range keymapPresets {
+		if preset.name == saved {
+			km.usePreset(index)
+			return
+		}
+	}
+
+	var custom map[string][]string
+	if err := json.Unmarshal([]byte(saved), &custom); err != nil {
+		return
+	}
+	if len(custom) == 0 {
+		return
+	}
+	keys := make([][]string, len(keyActionNames))
+	taken := []string{}
+	for action, name := range keyActionNames {
+		keys[action] = custom[name]
+		taken = append(taken, custom[name]...)
+	}
+	// actions added since the map was saved get their classic keys, unless taken
+	for action := range keys {
+		if len(keys[action]) > 0 {
+			continue
+		}
+		for _, pressed := range keymapPresets[0].keys[action] {
+			if !slices.Contains(taken, pressed) {
+				keys[action] = append(keys[action], pressed)
+			}
+		}
+	}
+	km.Preset = customKeymap
+	km.setKeys(keys)
+}
+
+// HelpBindings are the controls shown in game, moving is folded into one entry.
+func (km *KeyMap) HelpBindings() []key.Binding {
+	moveKeys := []string{}
+	for _, binding := range km.bindings[actionUp : actionRight+1] {
+		moveKeys = append(moveKeys, binding.Keys()...)
+	}
+	move := key.NewBinding(key.WithKeys(moveKeys...), key.WithHelp(km.moveHelpKey(), "move"))
+
+	return []key.Binding{move, km.bindings[actionBrake], km.bindings[actionHome], km.bindings[actionChat], quitBinding}
+}
+
+func (km *KeyMap) ShortHelp() []key.Binding {
+	return km.HelpBindings()
+}
+
+func (km *KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{km.HelpBindings()}
+}
+
+// moveHelpKey lists the move keys up, left, down, right, so the classic preset
+// reads "wasd/↑←↓→". Actions with different key counts are listed one by one.
+func (km *KeyMap) moveHelpKey() string {
+	moves := km.bindings[actionUp : actionRight+1]
+	groupCount := len(moves[0].Keys())
+	for _, binding := range moves {
+		if len(binding.Keys()) != groupCount {
+			names := []string{}
+			for _, binding := range moves {
+				names = append(names, binding.Help().Key)
+			}
+			return strings.Join(names, " ")
+		}
+	}
+
+	groups := []string{}
+	for group := range groupCount {
+		names := []string{}
+		separator := ""
+		for _, binding := range moves {
+			name := keyName(binding.Keys()[group])
+			if utf8.RuneCountInString(name) > 1 {
+				separator = " "
+			}
+			names = append(names, name)
+		}
+		groups = append(groups, strings.Join(names, separator))
+	}
+	return strings.Join(groups, "/")
+}
+
+func keyListName(keys []string) string {
+	names := make([]string, len(keys))
+	for i, pressed := range keys {
+		names[i] = keyName(pressed)
+	}
+	return strings.Join(names, "/")
+}
+
+// keyName is how a key is written on screen.
+func keyName(pressed string) string {
+	switch pressed {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case " ":
+		return "space"
+	default:
+		return pressed
+	}
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type OpenKeymapSettingsMsg struct{}
+type CloseKeymapSettingsMsg struct{}
+
+// KeymapSettingsModel remaps the in game controls one action at a time. It
+// edits the session's keymap in place, the setup screen saves it with the profile.
+type KeymapSettingsModel struct {
+	keys      *KeyMap
+	theme     *Theme
+	selected  int  // action being looked at
+	capturing bool // the next key pressed becomes the selected action's key
+	err       string
+	width     int
+	height    int
+}
+
+func NewKeymapSettingsModel(keys *KeyMap, theme *Theme, w, h int) KeymapSettingsModel {
+	return KeymapSettingsModel{keys: keys, theme: theme, width: w, height: h}
+}
+
+func (m KeymapSettingsModel) Init() tea.Cmd { return nil }
+
+func (m KeymapSettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		if m.capturing {
+			m.capturing = false
+			if msg.String() == "esc" {
+				return m, nil
+			}
+			if err := m.keys.rebind(keyAction(m.selected), msg.String()); err != nil {
+				m.err = err.Error()
+			}
+			return m, nil
+		}
+
+		m.err = ""
+		switch msg.String() {
+		case "up":
+			m.selected = (m.selected - 1 + len(keyActionNames)) % len(keyActionNames)
+		case "down":
+			m.selected = (m.selected + 1) % len(keyActionNames)
+		case "enter":
+			m.capturing = true
+		case "esc":
+			return m, func() tea.Msg { return CloseKeymapSettingsMsg{} }
+		}
+	}
+	return m, nil
+}
+
+func (m KeymapSettingsModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.theme.NewStyle().Bold(true).Render(fmt.Sprintf("Controls (%s)", m.keys.Preset)))
+	b.WriteString("\n\n")
+
+	for action, binding := range m.keys.bindings {
+		keys := binding.Help().Key
+		if m.capturing && action == m.selected {
+			keys = "press a key..."
+		}
+		row := fmt.Sprintf("%-12s %s", keyActionHelp[action], keys)
+		if action == m.selected {
+			b.WriteString(m.theme.Bind(focusedStyle).Render("> " + row))
+		} else {
+			b.WriteString(m.theme.Bind(blurredStyle).Render("  " + row))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if m.err != "" {
+		b.WriteString(m.theme.Bind(errorStyle).Render(m.err))
+	}
+	b.WriteString("\n\n")
+
+	help := "(↑/↓ to pick an action, enter to rebind it, esc to go back)"
+	if m.capturing {
+		help = "(esc to keep the current key)"
+	}
+	b.WriteString(m.theme.Bind(helpStyle).Render(help))
+
+	return m.theme.Renderer.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, b.String())
+}
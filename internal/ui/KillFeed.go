@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Mshel/ouroboros/internal/game"
+)
+
+const (
+	killFeedSize     = 4
+	killFeedTickLife = 300 // events fade from the feed after ~20s
+)
+
+// recentFeed picks the events still worth showing from the shared feed.
+func (m GameViewModel) recentFeed() []game.GameEvent {
+	tick := m.gameManager.GetTickSnapshot().Tick
+
+	feed := []game.GameEvent{}
+	for _, event := range m.gameManager.Events.Recent() {
+		if event.Tick+killFeedTickLife > tick {
+			feed = append(feed, event)
+		}
+	}
+	if len(feed) > killFeedSize {
+		feed = feed[len(feed)-killFeedSize:]
+	}
+	return feed
+}
+
+// feedLineCount is how many status panel lines renderKillFeed will take.
+func (m GameViewModel) feedLineCount() int {
+	if len(m.Feed) == 0 {
+		return 0
+	}
+	return 2 + len(m.Feed)
+}
+
+func (m GameViewModel) renderKillFeed(width int) string {
+	if len(m.Feed) == 0 {
+		return ""
+	}
+
+	playerName := func(name string, color int) string {
+		return m.theme.NewStyle().Foreground(m.theme.Color(color)).Render(name)
+	}
+
+	var sb strings.Builder
+	lineStyle := m.theme.NewStyle().MaxWidth(width)
+
+	sb.WriteString("\n" + m.theme.NewStyle().Bold(true).Render("--- Feed ---") + "\n")
+	for i := len(m.Feed) - 1; i >= 0; i-- {
+		event := m.Feed[i]
+		actor := playerName(event.ActorName, event.ActorColor)
+		victim := playerName(event.VictimName, event.VictimColor)
+
+		var line string
+		switch event.Kind {
+		case game.EventTailCut:
+			line = fmt.Sprintf("%s cut %s's tail", actor, victim)
+		case game.EventHeadOn:
+			line = fmt.Sprintf("%s and %s collided head-on", actor, victim)
+		case game.EventWall:
+			line = fmt.Sprintf("%s hit the wall", actor)
+		case game.EventClaim:
+			line = fmt.Sprintf("%s claimed %s tiles", actor, formatThousands(event.Tiles))
+		}
+		sb.WriteString(lineStyle.Render(line) + "\n")
+	}
+
+	return sb.String()
+}
+
+// formatThousands writes 2000 as 2,000.
+func formatThousands(number int) string {
+	digits := strconv.Itoa(number)
+	var sb strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(digit)
+	}
+	return sb.String()
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"github.com/Mshel/ouroboros/internal/game"
+)
+
+// mapViewCache holds the window of the map drawn last frame. A new frame only
+// copies what scrolled into view plus the chunks that changed since, instead
+// of the whole window under the map lock.
+type mapViewCache struct {
+	tiles    [][]game.Tile
+	startRow int
+	startCol int
+	version  uint64
+}
+
+func newMapViewCache() *mapViewCache {
+	return &mapViewCache{}
+}
+
+func (c *mapViewCache) window(gm *game.GameManager, startRow, endRow, startCol, endCol int) [][]game.Tile {
+	version := gm.MapVersions.Current()
+	height, width := endRow-startRow, endCol-startCol
+
+	// windows bigger than a wrapped map are folded by GetMapCopy, don't bother
+	if height > game.MapRowCount || width > game.MapColCount || c.tiles == nil {
+		c.reset(gm.GetMapCopy(startRow, endRow, startCol, endCol), startRow, startCol, version)
+		return c.tiles
+	}
+
+	tiles := make([][]game.Tile, height)
+	for row := range tiles {
+		tiles[row] = make([]game.Tile, width)
+	}
+
+	// what both windows share comes from the cache
+	overlapStartRow, overlapEndRow := max(startRow, c.startRow), min(endRow, c.startRow+len(c.tiles))
+	overlapStartCol, overlapEndCol := max(startCol, c.startCol), min(endCol, c.startCol+len(c.tiles[0]))
+	if overlapStartRow >= overlapEndRow || overlapStartCol >= overlapEndCol {
+		c.reset(gm.GetMapCopy(startRow, endRow, startCol, endCol), startRow, startCol, version)
+		return c.tiles
+	}
+	for row := overlapStartRow; row < overlapEndRow; row++ {
+		copy(tiles[row-startRow][overlapStartCol-startCol:overlapEndCol-startCol],
+			c.tiles[row-c.startRow][overlapStartCol-c.startCol:overlapEndCol-c.startCol])
+	}
+
+	// the strips that scrolled in, then everything that changed in the meantime
+	regions := []game.MapRegion{
+		{StartRow: startRow, EndRow: overlapStartRow, StartCol: startCol, EndCol: endCol},
+		{StartRow: overlapEndRow, EndRow: endRow, StartCol: startCol, EndCol: endCol},
+		{StartRow: overlapStartRow, EndRow: overlapEndRow, StartCol: startCol, EndCol: overlapStartCol},
+		{StartRow: overlapStartRow, EndRow: overlapEndRow, StartCol: overlapEndCol, EndCol: endCol},
+	}
+	regions = append(regions, gm.MapVersions.ChangedSince(c.version, startRow, endRow, startCol, endCol)...)
+
+	for _, region := range regions {
+		if region.StartRow >= region.EndRow || region.StartCol >= region.EndCol {
+			continue
+		}
+		patch := gm.GetMapCopy(region.StartRow, region.EndRow, region.StartCol, region.EndCol)
+		for row := range patch {
+			copy(tiles[region.StartRow-startRow+row][region.StartCol-startCol:], patch[row])
+		}
+	}
+
+	c.reset(tiles, startRow, startCol, version)
+	return c.tiles
+}
+
+func (c *mapViewCache) reset(tiles [][]game.Tile, startRow, startCol int, version uint64) {
+	c.tiles = tiles
+	c.startRow = startRow
+	c.startCol = startCol
+	c.version = version
+	if len(tiles) == 0 || len(tiles[0]) == 0 {
+		c.tiles = nil
+	}
+}
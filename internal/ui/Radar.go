@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"math"
+
+	"github.com/Mshel/ouroboros/internal/game"
+)
+
+const (
+	radarNearDistance = 15 // closer contacts are drawn bold
+	radarFarDistance  = 40 // farther ones are drawn faint
+)
+
+// Arrows by direction, clockwise from east since rows grow downwards. Heads
+// coming for your tail get plain arrows so they don't pass for heads on
+// screen, tails you could cut get hollow triangles.
+var (
+	radarThreatArrows = [8]string{"→", "↘", "↓", "↙", "←", "↖", "↑", "↗"}
+	radarTargetArrows = [8]string{"▷", "◿", "▽", "◺", "◁", "◸", "△", "◹"}
+)
+
+type radarMark struct {
+	contact game.RadarContact
+	glyph   string
+}
+
+// radarIndicators pins every off-screen radar contact to the viewport edge, on
+// the line from the middle of the view towards it. Threats win a shared cell,
+// then the closer contact.
+func (m GameViewModel) radarIndicators(currentPlayer *game.Player, startRow int, startCol int, viewHeight int, viewWidth int) map[[2]int]string {
+	indicators := map[[2]int]string{}
+	if viewHeight < 3 || viewWidth < 3 {
+		return indicators
+	}
+
+	head := currentPlayer.Location
+	centerRow, centerCol := float64(viewHeight-1)/2, float64(viewWidth-1)/2
+
+	marks := map[[2]int]radarMark{}
+	for _, contact := range m.gameManager.GetTickSnapshot().Radar[*currentPlayer.Color] {
+		row := head.Y + radarOffset(contact.Y-head.Y, game.MapRowCount) - startRow
+		col := head.X + radarOffset(contact.X-head.X, game.MapColCount) - startCol
+		if row >= 0 && row < viewHeight && col >= 0 && col < viewWidth {
+			continue
+		}
+
+		offsetRow, offsetCol := float64(row)-centerRow, float64(col)-centerCol
+		scale := math.Inf(1)
+		if offsetRow != 0 {
+			scale = math.Min(scale, centerRow/math.Abs(offsetRow))
+		}
+		if offsetCol != 0 {
+			scale = math.Min(scale, centerCol/math.Abs(offsetCol))
+		}
+		cell := [2]int{
+			min(viewHeight-1, max(0, int(math.Round(centerRow+offsetRow*scale)))),
+			min(viewWidth-1, max(0, int(math.Round(centerCol+offsetCol*scale)))),
+		}
+
+		if known, ok := marks[cell]; ok {
+			if known.contact.Threat && !contact.Threat {
+				continue
+			}
+			if known.contact.Threat == contact.Threat && known.contact.Distance <= contact.Distance {
+				continue
+			}
+		}
+
+		direction := int(math.Round(math.Atan2(offsetRow, offsetCol)/(math.Pi/4))+8) % 8
+		glyph := radarTargetArrows[direction]
+		if contact.Threat {
+			glyph = radarThreatArrows[direction]
+		}
+		marks[cell] = radarMark{contact: contact, glyph: glyph}
+	}
+
+	for cell, mark := range marks {
+		style := m.theme.NewStyle().Background(m.theme.Color(game.VoidColor)).
+			Foreground(m.theme.Color(mark.contact.Color))
+		switch {
+		case mark.contact.Distance <= radarNearDistance:
+			style = style.Bold(true)
+		case mark.contact.Distance > radarFarDistance:
+			style = style.Faint(true)
+		}
+		indicators[cell] = style.Render(mark.glyph)
+	}
+
+	return indicators
+}
+
+// radarOffset is the shortest way along an axis, around the back in a wrapping world.
+func radarOffset(offset int, size int) int {
+	if !game.WrapAround {
+		return offset
+	}
+	offset = ((offset % size) + size) % size
+	if offset > size/2 {
+		offset -= size
+	}
+	return offset
+}
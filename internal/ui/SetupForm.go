@@ -2,6 +2,7 @@ package ui
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
 )
 
 type ColorRefreshMsg struct{}
@@ -54,17 +56,20 @@ var (
 type SetupModel struct {
 	nameInput    textinput.Model
 	colorIndex   int // Index of the selected color (0-255)
-	focusIndex   int // 0: Name, 1: Color Select, 2: Submit/Quit
+	focusIndex   int // 0: Name, 1: Color Select, 2: Colorblind Mode, 3: Controls, 4: Submit/Quit
 	submitted    bool
 	width        int // Terminal width for wrapping
 	height       int // Terminal height
 	gameManager  *game.GameManager
 	colorOptions []string
 	nameError    error
+	theme        *Theme
+	keys         *KeyMap
+	profiles     *game.ProfileService
 	tea.Model
 }
 
-func NewInitialSetupModel(gameManager *game.GameManager, w, h int) SetupModel {
+func NewInitialSetupModel(gameManager *game.GameManager, profiles *game.ProfileService, theme *Theme, keys *KeyMap, w, h int) SetupModel {
 	// Validator function: checks for max 20 chars and alphanumeric/space characters
 	nameValidator := func(s string) error {
 		if len(s) > 20 {
@@ -88,6 +93,7 @@ func NewInitialSetupModel(gameManager *game.GameManager, w, h int) SetupModel {
 	ti.Width = 30
 	ti.PromptStyle = focusedStyle
 	ti.TextStyle = focusedStyle
+	theme.bindTextInput(&ti)
 
 	setupModel := SetupModel{
 		nameInput:   ti,
@@ -98,6 +104,9 @@ func NewInitialSetupModel(gameManager *game.GameManager, w, h int) SetupModel {
 		height:      h,
 		gameManager: gameManager,
 		nameError:   nil,
+		theme:       theme,
+		keys:        keys,
+		profiles:    profiles,
 	}
 
 	return setupModel
@@ -145,24 +154,44 @@ func (m SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if s == "enter" || s == "tab" || s == "shift+tab" {
 			switch m.focusIndex {
 			case 0: // Name Input
+				m.loadProfile()
 				switch s {
 				case "enter", "tab":
 					m.focusIndex = 1 // Move to Color Select
 					m.nameInput.Blur()
 				case "shift+tab":
-					m.focusIndex = 2 // Move to Submit
+					m.focusIndex = 4 // Move to Submit
+					m.nameInput.Blur()
 				}
 
 			case 1: // Color Select
 				switch s {
 				case "enter", "tab":
-					m.focusIndex = 2 // Move to Submit
+					m.focusIndex = 2 // Move to Colorblind Mode
 				case "shift+tab":
 					m.focusIndex = 0 // Move to Name Input
 					m.nameInput.Focus()
 				}
 
-			case 2: // Submit Button
+			case 2: // Colorblind Mode
+				switch s {
+				case "enter", "tab":
+					m.focusIndex = 3 // Move to Controls
+				case "shift+tab":
+					m.focusIndex = 1 // Move to Color Select
+				}
+
+			case 3: // Controls
+				switch s {
+				case "enter":
+					return m, func() tea.Msg { return OpenKeymapSettingsMsg{} }
+				case "tab":
+					m.focusIndex = 4 // Move to Submit
+				case "shift+tab":
+					m.focusIndex = 2 // Move to Colorblind Mode
+				}
+
+			case 4: // Submit Button
 				switch s {
 				case "enter":
 					err := validateName(m.nameInput.Value())
@@ -177,20 +206,45 @@ func (m SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.submitted = true
 					return m, func() tea.Msg {
 						return SetupSubmitMsg{
-							Name:  m.nameInput.Value(),
-							Color: m.colorOptions[m.colorIndex],
+							Name:           m.nameInput.Value(),
+							Color:          m.colorOptions[m.colorIndex],
+							ColorblindMode: m.theme.Colorblind,
+							Keymap:         m.keys.Encode(),
 						}
 					}
 				case "tab":
 					m.focusIndex = 0 // Cycle to Name Input
 					m.nameInput.Focus()
 				case "shift+tab":
-					m.focusIndex = 1 // Move to Color Select
+					m.focusIndex = 3 // Move to Controls
 				}
 			}
 			return m, nil
 		}
 
+		// The colorblind mode applies right away so the swatches show what you'll get
+		if m.focusIndex == 2 {
+			switch s {
+			case "left":
+				m.theme.SetColorblindMode(m.theme.Colorblind.next(-1))
+				return m, nil
+			case "right":
+				m.theme.SetColorblindMode(m.theme.Colorblind.next(1))
+				return m, nil
+			}
+		}
+
+		if m.focusIndex == 3 {
+			switch s {
+			case "left":
+				m.keys.cyclePreset(-1)
+				return m, nil
+			case "right":
+				m.keys.cyclePreset(1)
+				return m, nil
+			}
+		}
+
 		// 3. Handle Color Selection Navigation (Arrows, only when focused on colors)
 		if m.focusIndex == 1 {
 			var keyConsumed bool
@@ -232,7 +286,7 @@ func (m SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m SetupModel) View() string {
 	center := func(s string) string {
-		return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(s)
+		return m.theme.NewStyle().Width(m.width).Align(lipgloss.Center).Render(s)
 	}
 
 	if len(m.colorOptions) == 0 {
@@ -242,7 +296,7 @@ func (m SetupModel) View() string {
 	var b strings.Builder
 
 	if m.nameError != nil {
-		b.WriteString(center(errorStyle.Render("Error: " + m.nameError.Error())))
+		b.WriteString(center(m.theme.Bind(errorStyle).Render("Error: " + m.nameError.Error())))
 		b.WriteString("\n")
 	}
 
@@ -253,32 +307,39 @@ func (m SetupModel) View() string {
 	orborusColorPrompt := "Select your ouroboros color(use arrows)"
 	var colorPrompt string
 	if m.focusIndex == 1 {
-		colorPrompt = focusedStyle.Render(orborusColorPrompt)
+		colorPrompt = m.theme.Bind(focusedStyle).Render(orborusColorPrompt)
 	} else {
-		colorPrompt = blurredStyle.Render(orborusColorPrompt)
+		colorPrompt = m.theme.Bind(blurredStyle).Render(orborusColorPrompt)
 	}
 	b.WriteString(center(colorPrompt))
 	b.WriteString("\n")
 
 	var colorSwatches strings.Builder
-	var selectedColorCode string
+	selectedColor := -1
 
 	colorsPerLine := 30
 
 	for i, colorCode := range m.colorOptions {
-		style := colorSwatchStyle.
-			Background(lipgloss.Color(colorCode))
+		color, _ := strconv.Atoi(colorCode)
+		style := m.theme.Bind(colorSwatchStyle).
+			Background(m.theme.Color(color))
 
 		swatchChar := "█"
+		// look-alike colors show the land pattern they would get instead
+		if m.theme.Shared(color) {
+			style = m.theme.Bind(colorSwatchStyle)
+			swatchChar = m.theme.Glyph(color)
+		}
+
 		if i == m.colorIndex && m.focusIndex == 1 {
-			swatch := style.Foreground(lipgloss.Color(colorCode)).Render(swatchChar)
-			colorSwatches.WriteString(selectedSwatchBorderStyle.Render(swatch))
-			selectedColorCode = colorCode
+			swatch := style.Foreground(m.theme.Color(color)).Render(swatchChar)
+			colorSwatches.WriteString(m.theme.Bind(selectedSwatchBorderStyle).Render(swatch))
+			selectedColor = color
 		} else if i == m.colorIndex {
-			colorSwatches.WriteString(style.Foreground(lipgloss.Color(colorCode)).Render(swatchChar))
-			selectedColorCode = colorCode
+			colorSwatches.WriteString(style.Foreground(m.theme.Color(color)).Render(swatchChar))
+			selectedColor = color
 		} else {
-			colorSwatches.WriteString(style.Foreground(lipgloss.Color(colorCode)).Render(swatchChar))
+			colorSwatches.WriteString(style.Foreground(m.theme.Color(color)).Render(swatchChar))
 		}
 
 		if (i+1)%colorsPerLine == 0 && i < len(m.colorOptions)-1 {
@@ -288,29 +349,53 @@ func (m SetupModel) View() string {
 	b.WriteString(center(colorSwatches.String()))
 	b.WriteString("\n")
 
-	b.WriteString(center("Ourboros color " + selectedColorStyle.
-		Foreground(lipgloss.Color(selectedColorCode)).
-		Render("██")))
+	selectedSwatch := "██"
+	if m.theme.Shared(selectedColor) {
+		selectedSwatch = strings.Repeat(m.theme.Glyph(selectedColor), 2)
+	}
+	b.WriteString(center("Ourboros color " + m.theme.Bind(selectedColorStyle).
+		Foreground(m.theme.Color(selectedColor)).
+		Render(selectedSwatch)))
+
+	b.WriteString("\n")
+	b.WriteString("\n")
+
+	// Colorblind Mode
+	colorblindMode := fmt.Sprintf("Colors: < %s >", m.theme.Colorblind)
+	if m.focusIndex == 2 {
+		b.WriteString(center(m.theme.Bind(focusedStyle).Render(colorblindMode)))
+	} else {
+		b.WriteString(center(m.theme.Bind(blurredStyle).Render(colorblindMode)))
+	}
+	b.WriteString("\n")
+	b.WriteString("\n")
 
+	// Controls
+	controls := fmt.Sprintf("Controls: < %s >", m.keys.Preset)
+	if m.focusIndex == 3 {
+		b.WriteString(center(m.theme.Bind(focusedStyle).Render(controls + " (enter to customize)")))
+	} else {
+		b.WriteString(center(m.theme.Bind(blurredStyle).Render(controls)))
+	}
 	b.WriteString("\n")
 	b.WriteString("\n")
 
 	// Submit Button
 	submitText := "Submit"
 	var submitButton string
-	if m.focusIndex == 2 {
-		submitButton = submitButtonStyle.Render(submitText)
+	if m.focusIndex == 4 {
+		submitButton = m.theme.Bind(submitButtonStyle).Render(submitText)
 	} else {
-		submitButton = blurredButtonStyle.Padding(0, 1).Render(submitText)
+		submitButton = m.theme.Bind(blurredButtonStyle).Padding(0, 1).Render(submitText)
 	}
 	b.WriteString(center(submitButton))
 	b.WriteString("\n\n")
 
 	// Help Text
-	b.WriteString(center(helpStyle.Render("(arrows to select color, tab/shift+tab to navigate, enter to confirm, ctrl+c to quit)")))
+	b.WriteString(center(m.theme.Bind(helpStyle).Render("(arrows to select color, colors mode and controls, tab/shift+tab to navigate, enter to confirm, ctrl+c to quit)")))
 
 	// Final centering (this centers the whole block vertically, but the individual lines are now centered horizontally)
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, b.String())
+	return m.theme.Renderer.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, b.String())
 }
 
 func validateName(name string) error {
@@ -331,3 +416,23 @@ func validateName(name string) error {
 	}
 	return nil
 }
+
+// loadProfile brings back the colorblind mode and controls saved under the typed name.
+func (m *SetupModel) loadProfile() {
+	name := m.nameInput.Value()
+	if validateName(name) != nil {
+		return
+	}
+
+	profile, _, err := m.profiles.GetProfile(name)
+	if err != nil {
+		log.Warn("Could not load profile", "error", err)
+		return
+	}
+	if profile.ColorblindMode != "" {
+		m.theme.SetColorblindMode(ParseColorblindMode(profile.ColorblindMode))
+	}
+	if profile.Keymap != "" {
+		m.keys.Load(profile.Keymap)
+	}
+}
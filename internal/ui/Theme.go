@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Mshel/ouroboros/internal/game"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+	"github.com/muesli/termenv"
+)
+
+// landGlyphs tell apart land of colors that look the same in a session's
+// color profile. The first color of every look-alike group keeps the usual rune.
+var landGlyphs = []string{claimedEstateRune, "░", "▓", "▚", "▞", "█", "▙", "▟", "▛", "▜"}
+
+// Theme is how one ssh session sees the game: a lipgloss renderer bound to
+// the client's color profile, and every game color mapped into that profile.
+type Theme struct {
+	Renderer   *lipgloss.Renderer
+	Profile    termenv.Profile
+	Colorblind ColorblindMode
+
+	colors [256]lipgloss.TerminalColor
+	glyphs [256]string
+	shared [256]bool // another color looks the same in this profile
+
+	wall    string
+	void    string
+	noBuild string
+}
+
+func NewTheme(session ssh.Session) *Theme {
+	renderer := lipgloss.DefaultRenderer()
+	profile := renderer.ColorProfile()
+	if session != nil {
+		profile = detectColorProfile(session)
+		renderer = lipgloss.NewRenderer(session)
+		renderer.SetColorProfile(profile)
+		// the background is never queried, that would eat the client's input
+		renderer.SetHasDarkBackground(true)
+	}
+
+	theme := &Theme{Renderer: renderer, Profile: profile}
+	theme.buildPalette()
+
+	return theme
+}
+
+// SetColorblindMode switches the session to another accessibility mode.
+func (t *Theme) SetColorblindMode(mode ColorblindMode) {
+	t.Colorblind = mode
+	t.buildPalette()
+}
+
+// Patterns tells whether rivals are drawn with their own patterns.
+func (t *Theme) Patterns() bool {
+	return t.Colorblind != ColorblindOff
+}
+
+// detectColorProfile reads the profile from what the client sent: TERM from
+// the pty request and COLORTERM from the environment, if it forwards it.
+func detectColorProfile(session ssh.Session) termenv.Profile {
+	environ := map[string]string{}
+	for _, variable := range session.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
+		environ[name] = value
+	}
+
+	term := environ["TERM"]
+	if pty, _, ok := session.Pty(); ok && pty.Term != "" {
+		term = pty.Term
+	}
+	colorTerm := strings.ToLower(environ["COLORTERM"])
+
+	switch {
+	case term == "" || term == "dumb":
+		return termenv.Ascii
+	case colorTerm == "truecolor" || colorTerm == "24bit":
+		return termenv.TrueColor
+	case strings.HasSuffix(term, "-direct") || strings.Contains(term, "truecolor"):
+		return termenv.TrueColor
+	case strings.Contains(term, "256color"):
+		return termenv.ANSI256
+	default:
+		return termenv.ANSI
+	}
+}
+
+// buildPalette maps every game color into the profile and hands out glyphs to
+// the colors that end up looking alike. The wall goes first so no player's land
+// can pass for it, the 16 basic colors go last as the 6x6x6 cube repeats them.
+func (t *Theme) buildPalette() {
+	order := []int{game.WallColor}
+	for i := range 256 {
+		gameColor := (i + 16) % 256
+		if gameColor != game.WallColor {
+			order = append(order, gameColor)
+		}
+	}
+
+	var keys [256]string
+	groupSizes := map[string]int{}
+	for _, gameColor := range order {
+		t.colors[gameColor], keys[gameColor] = t.mapColor(gameColor)
+		t.glyphs[gameColor] = landGlyph(groupSizes[keys[gameColor]])
+		groupSizes[keys[gameColor]]++
+	}
+
+	for gameColor := range 256 {
+		t.shared[gameColor] = groupSizes[keys[gameColor]] > 1
+	}
+
+	voidBackground := t.NewStyle().Background(t.Color(game.VoidColor))
+	t.wall = t.NewStyle().Foreground(t.Color(game.WallColor)).Render(claimedEstateRune)
+	t.void = voidBackground.Render(" ")
+	t.noBuild = voidBackground.Foreground(t.Color(game.NoBuildColor)).Render("·")
+}
+
+// landGlyph is the glyph for the n-th color of a look-alike group. Only the
+// first one gets the usual rune, which keeps the wall unique even without colors.
+func landGlyph(n int) string {
+	if n == 0 {
+		return landGlyphs[0]
+	}
+	return landGlyphs[1+(n-1)%(len(landGlyphs)-1)]
+}
+
+// mapColor returns the color to draw with and a key that is equal for colors
+// the client can't tell apart. Red-green modes correct player colors first,
+// the system colors stay as they are.
+func (t *Theme) mapColor(gameColor int) (lipgloss.TerminalColor, string) {
+	r, g, b := game.PaletteRGB(gameColor)
+	_, isSystemColor := game.SystemColors[gameColor]
+	corrected := !isSystemColor && (t.Colorblind == ColorblindDeuteranopia || t.Colorblind == ColorblindProtanopia)
+	if corrected {
+		r, g, b = daltonize(t.Colorblind, r, g, b)
+	}
+	hex := fmt.Sprintf("#%02x%02x%02x", r, g, b)
+
+	switch t.Profile {
+	case termenv.TrueColor:
+		return lipgloss.Color(hex), hex
+	case termenv.ANSI256:
+		paletteColor := gameColor
+		// the 16 basic colors follow the client's theme, the corrected ones stay out of them
+		if corrected {
+			paletteColor = nearestPaletteColor(r, g, b, 16, 256, -1)
+		}
+		return lipgloss.Color(strconv.Itoa(paletteColor)), strconv.Itoa(paletteColor)
+	case termenv.ANSI:
+		// player colors skip the one the void is drawn with, their land would vanish into it
+		skip := -1
+		if !isSystemColor {
+			voidR, voidG, voidB := game.PaletteRGB(game.VoidColor)
+			skip = nearestPaletteColor(voidR, voidG, voidB, 0, 16, -1)
+		}
+		ansiColor := nearestPaletteColor(r, g, b, 0, 16, skip)
+		return lipgloss.Color(strconv.Itoa(ansiColor)), strconv.Itoa(ansiColor)
+	default:
+		return lipgloss.NoColor{}, ""
+	}
+}
+
+// nearestPaletteColor picks the terminal color in [from, to) closest to the RGB.
+func nearestPaletteColor(r, g, b uint8, from, to, skip int) int {
+	nearest, nearestDistance := from, -1
+	for paletteColor := from; paletteColor < to; paletteColor++ {
+		if paletteColor == skip {
+			continue
+		}
+		paletteR, paletteG, paletteB := game.PaletteRGB(paletteColor)
+		dr, dg, db := int(r)-int(paletteR), int(g)-int(paletteG), int(b)-int(paletteB)
+		distance := dr*dr + dg*dg + db*db
+		if nearestDistance < 0 || distance < nearestDistance {
+			nearest, nearestDistance = paletteColor, distance
+		}
+	}
+	return nearest
+}
+
+func (t *Theme) NewStyle() lipgloss.Style {
+	return t.Renderer.NewStyle()
+}
+
+// Bind moves a package level style onto the session's renderer.
+func (t *Theme) Bind(style lipgloss.Style) lipgloss.Style {
+	return style.Renderer(t.Renderer)
+}
+
+func (t *Theme) Color(gameColor int) lipgloss.TerminalColor {
+	if gameColor < 0 || gameColor > 255 {
+		return lipgloss.NoColor{}
+	}
+	return t.colors[gameColor]
+}
+
+// Glyph is the rune a color's land is drawn with.
+func (t *Theme) Glyph(gameColor int) string {
+	if gameColor < 0 || gameColor > 255 {
+		return claimedEstateRune
+	}
+	return t.glyphs[gameColor]
+}
+
+// Shared tells whether another color looks the same in this profile.
+func (t *Theme) Shared(gameColor int) bool {
+	return gameColor >= 0 && gameColor <= 255 && t.shared[gameColor]
+}
+
+// Marker stands for a player next to their name, colors that look alike show
+// their land glyph so they can be matched with the map.
+func (t *Theme) Marker(gameColor int) string {
+	if t.Shared(gameColor) {
+		return t.glyphs[gameColor]
+	}
+	return "●"
+}
+
+func (t *Theme) bindTextInput(ti *textinput.Model) {
+	ti.PromptStyle = t.Bind(ti.PromptStyle)
+	ti.TextStyle = t.Bind(ti.TextStyle)
+	ti.PlaceholderStyle = t.Bind(ti.PlaceholderStyle)
+	ti.CompletionStyle = t.Bind(ti.CompletionStyle)
+	ti.Cursor.Style = t.Bind(ti.Cursor.Style)
+	ti.Cursor.TextStyle = t.Bind(ti.Cursor.TextStyle)
+}
+
+func (t *Theme) bindHelp(h *help.Model) {
+	h.Styles.Ellipsis = t.Bind(h.Styles.Ellipsis)
+	h.Styles.ShortKey = t.Bind(h.Styles.ShortKey)
+	h.Styles.ShortDesc = t.Bind(h.Styles.ShortDesc)
+	h.Styles.ShortSeparator = t.Bind(h.Styles.ShortSeparator)
+	h.Styles.FullKey = t.Bind(h.Styles.FullKey)
+	h.Styles.FullDesc = t.Bind(h.Styles.FullDesc)
+	h.Styles.FullSeparator = t.Bind(h.Styles.FullSeparator)
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mshel/ouroboros/internal/game"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	timelineMaxWidth    = 60
+	timelineChartHeight = 3 // sparkline, moment markers and the time axis
+	sparklineColor      = "87"
+)
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// renderSparkline squeezes the values into width columns, every column shows
+// the biggest value it covers so short peaks don't get lost.
+func renderSparkline(values []int, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	columns := min(width, len(values))
+
+	peak := 0
+	for _, value := range values {
+		peak = max(peak, value)
+	}
+
+	var sb strings.Builder
+	for column := range columns {
+		from, to := column*len(values)/columns, max(column*len(values)/columns+1, (column+1)*len(values)/columns)
+		value := 0
+		for _, sample := range values[from:to] {
+			value = max(value, sample)
+		}
+		level := 0
+		if peak > 0 {
+			level = value * (len(sparkBlocks) - 1) / peak
+		}
+		sb.WriteRune(sparkBlocks[level])
+	}
+	return sb.String()
+}
+
+func formatTicks(ticks uint64) string {
+	elapsed := time.Duration(ticks) * game.GameTickDuration
+	return fmt.Sprintf("%d:%02d", int(elapsed.Minutes()), int(elapsed.Seconds())%60)
+}
+
+// renderTimeline draws land over the player's life with a marker under the
+// moment anything happened, then lists as many moments as fit, latest last.
+// The game over screen shows it for the life just lost, the leaderboard for
+// any score that kept one.
+func renderTimeline(theme *Theme, summary *game.LifeSummary, width int, lines int) string {
+	if summary == nil || len(summary.Territory) == 0 || lines < timelineChartHeight {
+		return ""
+	}
+
+	sparkline := renderSparkline(summary.Territory, min(width, timelineMaxWidth))
+	chartWidth := lipgloss.Width(sparkline)
+	lifeTicks := max(1, uint64(summary.TimeAlive/game.GameTickDuration))
+
+	markers := []rune(strings.Repeat(" ", chartWidth))
+	markerColors := make([]int, chartWidth)
+	for _, moment := range summary.Moments {
+		column := min(chartWidth-1, int(moment.Tick*uint64(chartWidth)/lifeTicks))
+		// the killing blow always shows, kills win over claims
+		if markers[column] == '✖' || (markers[column] == '✦' && moment.Kind == game.MomentClaim) {
+			continue
+		}
+		markers[column], markerColors[column] = momentMarker(moment), moment.OtherColor
+	}
+
+	var markerLine strings.Builder
+	for column, marker := range markers {
+		style := theme.NewStyle()
+		if markerColors[column] >= 0 && marker != ' ' && marker != '◆' {
+			style = style.Foreground(theme.Color(markerColors[column]))
+		}
+		markerLine.WriteString(style.Render(string(marker)))
+	}
+
+	endLabel := formatTicks(lifeTicks)
+	axis := "0:00" + strings.Repeat(" ", max(1, chartWidth-4-len(endLabel))) + endLabel
+
+	var sb strings.Builder
+	sb.WriteString(theme.NewStyle().Foreground(lipgloss.Color(sparklineColor)).Render(sparkline) + "\n")
+	sb.WriteString(markerLine.String() + "\n")
+	sb.WriteString(theme.NewStyle().Faint(true).Render(axis))
+
+	moments := summary.Moments[max(0, len(summary.Moments)-(lines-timelineChartHeight)):]
+	for _, moment := range moments {
+		sb.WriteString("\n" + theme.NewStyle().MaxWidth(width).Render(formatTicks(moment.Tick)+"  "+momentText(theme, moment)))
+	}
+
+	// one block so the screen centers it as a whole and the moments line up
+	return theme.NewStyle().Width(lipgloss.Width(sb.String())).Render(sb.String())
+}
+
+func momentMarker(moment game.Moment) rune {
+	switch moment.Kind {
+	case game.MomentKill:
+		return '✦'
+	case game.MomentClaim:
+		return '◆'
+	default:
+		return '✖'
+	}
+}
+
+func momentText(theme *Theme, moment game.Moment) string {
+	other := theme.NewStyle().Foreground(theme.Color(moment.OtherColor)).Render(moment.OtherName)
+
+	switch {
+	case moment.Kind == game.MomentKill:
+		return fmt.Sprintf("%c cut %s's tail", momentMarker(moment), other)
+	case moment.Kind == game.MomentClaim:
+		return fmt.Sprintf("%c claimed %s tiles in one loop", momentMarker(moment), formatThousands(moment.Tiles))
+	case moment.OtherColor < 0:
+		return fmt.Sprintf("%c hit the wall", momentMarker(moment))
+	case moment.HeadOn:
+		return fmt.Sprintf("%c went down head-on with %s", momentMarker(moment), other)
+	default:
+		return fmt.Sprintf("%c tail cut by %s", momentMarker(moment), other)
+	}
+}
@@ -0,0 +1,223 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mshel/ouroboros/internal/game"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type CloseTutorialMsg struct{}
+
+// tutorialTickMsg carries its sandbox so a tick left over from an earlier
+// tutorial doesn't speed up the next one.
+type tutorialTickMsg struct {
+	sandbox *game.Sandbox
+}
+
+const (
+	tutorialTailLength  = 8
+	tutorialTopSpeed    = 2
+	tutorialNoticeTicks = 40
+)
+
+// tutorialStep is one prompt of the tutorial, done compares what the player
+// did against the progress the step started with.
+type tutorialStep struct {
+	prompt func(keys *KeyMap) string
+	done   func(now game.SandboxProgress, start game.SandboxProgress) bool
+}
+
+var tutorialSteps = []tutorialStep{
+	{
+		prompt: func(keys *KeyMap) string {
+			return fmt.Sprintf("Steer your snake with %s, make two turns.", keys.moveHelpKey())
+		},
+		done: func(now, start game.SandboxProgress) bool { return now.Turns-start.Turns >= 2 },
+	},
+	{
+		prompt: func(keys *KeyMap) string {
+			return fmt.Sprintf("Leave your land and stretch your tail to %d tiles.", tutorialTailLength)
+		},
+		done: func(now, start game.SandboxProgress) bool { return now.TailLength >= tutorialTailLength },
+	},
+	{
+		prompt: func(keys *KeyMap) string {
+			return "Get back home, whatever your tail encloses becomes yours."
+		},
+		done: func(now, start game.SandboxProgress) bool { return now.Claims > start.Claims },
+	},
+	{
+		prompt: func(keys *KeyMap) string {
+			return "Run into the dummy's tail, a cut tail kills its snake."
+		},
+		done: func(now, start game.SandboxProgress) bool { return now.Kills > start.Kills },
+	},
+	{
+		prompt: func(keys *KeyMap) string {
+			return fmt.Sprintf("Press your direction again to speed up, reach speed %d.", tutorialTopSpeed)
+		},
+		done: func(now, start game.SandboxProgress) bool { return now.Speed >= tutorialTopSpeed },
+	},
+	{
+		prompt: func(keys *KeyMap) string {
+			return fmt.Sprintf("Slow down with the opposite direction or %s.", keys.bindings[actionBrake].Help().Key)
+		},
+		done: func(now, start game.SandboxProgress) bool { return now.SlowDowns > start.SlowDowns },
+	},
+}
+
+// TutorialModel walks a new player through the basics in a sandbox of their
+// own, every step moves on as soon as the player has done it.
+type TutorialModel struct {
+	sandbox     *game.Sandbox
+	keys        *KeyMap
+	theme       *Theme
+	step        int
+	stepStart   game.SandboxProgress
+	notice      string
+	noticeTicks int
+	width       int
+	height      int
+}
+
+func NewTutorialModel(sandbox *game.Sandbox, keys *KeyMap, theme *Theme, w, h int) TutorialModel {
+	return TutorialModel{sandbox: sandbox, keys: keys, theme: theme, width: w, height: h}
+}
+
+func (m TutorialModel) Init() tea.Cmd {
+	return m.nextTick()
+}
+
+func (m TutorialModel) nextTick() tea.Cmd {
+	sandbox := m.sandbox
+	return tea.Tick(game.GameTickDuration, func(time.Time) tea.Msg { return tutorialTickMsg{sandbox: sandbox} })
+}
+
+func (m TutorialModel) finished() bool {
+	return m.step >= len(tutorialSteps)
+}
+
+func (m TutorialModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tutorialTickMsg:
+		if msg.sandbox != m.sandbox {
+			return m, nil
+		}
+		deaths := m.sandbox.Progress().Deaths
+		m.sandbox.Step()
+		progress := m.sandbox.Progress()
+
+		if m.noticeTicks > 0 {
+			m.noticeTicks--
+		}
+		if progress.Deaths > deaths {
+			m.notice, m.noticeTicks = "That would have killed you in the real game, you lost your tail.", tutorialNoticeTicks
+		}
+		if !m.finished() && tutorialSteps[m.step].done(progress, m.stepStart) {
+			m.step++
+			m.stepStart = progress
+			m.notice, m.noticeTicks = "Nice!", tutorialNoticeTicks/2
+		}
+		return m, m.nextTick()
+
+	case tea.KeyMsg:
+		if msg.String() == "esc" {
+			return m, func() tea.Msg { return CloseTutorialMsg{} }
+		}
+		if m.finished() && msg.String() == "enter" {
+			return m, func() tea.Msg { return IntroRegister }
+		}
+
+		action, bound := m.keys.Action(msg)
+		if !bound {
+			return m, nil
+		}
+		switch action {
+		case actionUp:
+			m.sandbox.Press(game.Direction{Dx: 0, Dy: -1})
+		case actionDown:
+			m.sandbox.Press(game.Direction{Dx: 0, Dy: 1})
+		case actionLeft:
+			m.sandbox.Press(game.Direction{Dx: -1, Dy: 0})
+		case actionRight:
+			m.sandbox.Press(game.Direction{Dx: 1, Dy: 0})
+		case actionBrake:
+			m.sandbox.Brake()
+		}
+	}
+	return m, nil
+}
+
+func (m TutorialModel) View() string {
+	player := m.sandbox.Player
+
+	title := fmt.Sprintf("How to Play  %d/%d", min(m.step+1, len(tutorialSteps)), len(tutorialSteps))
+	prompt := "All done! Press enter to pick a name and play."
+	if !m.finished() {
+		prompt = tutorialSteps[m.step].prompt(m.keys)
+	}
+
+	status := fmt.Sprintf("Land: %d  Tail: %d  Speed: %d", m.sandbox.Land(*player.Color), m.sandbox.Progress().TailLength, player.Speed)
+	if m.noticeTicks > 0 {
+		status = m.notice
+	}
+
+	board := m.theme.Bind(mapViewStyle).Render(m.renderSandbox())
+	textStyle := m.theme.NewStyle().Width(lipgloss.Width(board)).Align(lipgloss.Center)
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		m.theme.NewStyle().Bold(true).Render(title),
+		board,
+		textStyle.Bold(true).Foreground(focusedColor).Render(prompt),
+		textStyle.Render(status),
+		m.theme.Bind(helpStyle).Render("esc back to the menu"),
+	)
+
+	return m.theme.Renderer.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
+
+// renderSandbox draws the whole sandbox like the game draws its map, it is
+// small enough to never scroll.
+func (m TutorialModel) renderSandbox() string {
+	mapSegment := m.sandbox.MapCopy()
+	heads := map[*game.Tile]*game.Player{m.sandbox.Player.Location: m.sandbox.Player}
+	if !m.sandbox.DummyDead {
+		heads[m.sandbox.Dummy.Location] = m.sandbox.Dummy
+	}
+
+	var sb strings.Builder
+	for row := range mapSegment {
+		for col, tile := range mapSegment[row] {
+			tileStyle := m.theme.NewStyle().Background(m.theme.Color(game.VoidColor))
+
+			if head, ok := heads[m.sandbox.GameMap[row][col]]; ok {
+				sb.WriteString(tileStyle.Foreground(m.theme.Color(*head.Color)).Bold(true).
+					Render(string(headRunes[game.Direction{Dx: head.CurrentDirection.Dx, Dy: head.CurrentDirection.Dy}])))
+				continue
+			}
+
+			switch {
+			case tile.Terrain == game.TerrainWall:
+				sb.WriteString(m.theme.wall)
+			case tile.OwnerColor == nil:
+				sb.WriteString(m.theme.void)
+			case tile.IsTail:
+				sb.WriteString(tileStyle.Foreground(m.theme.Color(*tile.OwnerColor)).Render(tailRune(mapSegment, row, col, tailStyles[0])))
+			default:
+				sb.WriteString(tileStyle.Foreground(m.theme.Color(*tile.OwnerColor)).Render(m.theme.Glyph(*tile.OwnerColor)))
+			}
+		}
+		if row < len(mapSegment)-1 {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
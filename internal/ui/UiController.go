@@ -1,10 +1,13 @@
 package ui
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/Mshel/ouroboros/internal/game"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
 	"github.com/charmbracelet/ssh"
 )
 
@@ -16,13 +19,29 @@ const (
 	GameScreen
 	GameOverScreen
 	LeaderboardScreen
+	KeymapSettingsScreen
+	TutorialScreen
+)
+
+const (
+	minScreenWidth  = 60
+	minScreenHeight = 24
 )
 
 // Messages for state transitions
-type IntroSubmitMsg int // 0 for Register, 1 for Leaderboard
+type IntroSubmitMsg int // the intro button picked
+
+const (
+	IntroRegister IntroSubmitMsg = iota
+	IntroLeaderboard
+	IntroTutorial
+)
+
 type SetupSubmitMsg struct {
-	Name  string
-	Color string
+	Name           string
+	Color          string
+	ColorblindMode ColorblindMode
+	Keymap         string
 }
 
 type ControllerModel struct {
@@ -34,31 +53,61 @@ type ControllerModel struct {
 	GameModel        tea.Model
 	GameOverModel    tea.Model
 	LeaderboardModel tea.Model
+	SettingsModel    tea.Model
+	TutorialModel    tea.Model
 
 	CurrentUserSession ssh.Session
+	Theme              *Theme
+	Keys               *KeyMap
+	PlayerName         string // the ssh user's name if it has a profile, then the name the player joins under
+	Profiles           *game.ProfileService
 	ScreenWidth        int
 	ScreenHeight       int
 }
 
-func NewControllerModel(gameManager *game.GameManager, userSession ssh.Session, screenWidth int, screenHeight int) ControllerModel {
+func NewControllerModel(gameManager *game.GameManager, profiles *game.ProfileService, userSession ssh.Session, screenWidth int, screenHeight int) ControllerModel {
+	theme := NewTheme(userSession)
+	keys := NewKeyMap()
+
 	return ControllerModel{
 		GameManager:   gameManager,
 		CurrentScreen: IntroScreen,
 
-		IntroModel: NewIntroModel(screenWidth, screenHeight),
-		SetupModel: NewInitialSetupModel(gameManager, screenWidth, screenHeight),
+		IntroModel: NewIntroModel(theme, screenWidth, screenHeight),
+		SetupModel: NewInitialSetupModel(gameManager, profiles, theme, keys, screenWidth, screenHeight),
 
 		CurrentUserSession: userSession,
+		Theme:              theme,
+		Keys:               keys,
+		Profiles:           profiles,
+		PlayerName:         sshProfileName(profiles, userSession),
 		ScreenWidth:        screenWidth,
 		ScreenHeight:       screenHeight,
 	}
 }
 
+// sshProfileName is the ssh user's name when someone played under it before,
+// so the leaderboard can find the player's rank before they join a game.
+func sshProfileName(profiles *game.ProfileService, userSession ssh.Session) string {
+	if userSession == nil || validateName(userSession.User()) != nil {
+		return ""
+	}
+	if _, found, err := profiles.GetProfile(userSession.User()); err != nil || !found {
+		return ""
+	}
+	return userSession.User()
+}
+
 func (m ControllerModel) Init() tea.Cmd {
 	return m.IntroModel.Init()
 }
 
 func (m ControllerModel) View() string {
+	if m.ScreenWidth < minScreenWidth || m.ScreenHeight < minScreenHeight {
+		return m.Theme.Renderer.Place(m.ScreenWidth, m.ScreenHeight, lipgloss.Center, lipgloss.Center,
+			fmt.Sprintf("Terminal too small: %dx%d\nneeds at least %dx%d", m.ScreenWidth, m.ScreenHeight, minScreenWidth, minScreenHeight))
+	}
+
 	switch m.CurrentScreen {
 	case IntroScreen:
 		return m.IntroModel.View()
@@ -79,6 +128,16 @@ func (m ControllerModel) View() string {
 			return m.LeaderboardModel.View()
 		}
 		return "Leaderboard Loading..."
+	case KeymapSettingsScreen:
+		if m.SettingsModel != nil {
+			return m.SettingsModel.View()
+		}
+		return "Settings Loading..."
+	case TutorialScreen:
+		if m.TutorialModel != nil {
+			return m.TutorialModel.View()
+		}
+		return "Tutorial Loading..."
 	default:
 		return "Unknown Screen"
 	}
@@ -96,25 +155,60 @@ func (m ControllerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// every screen keeps the size, the ones created later start with it too
+		m.ScreenWidth = msg.Width
+		m.ScreenHeight = msg.Height
+		m.IntroModel, _ = m.IntroModel.Update(msg)
+		m.SetupModel, _ = m.SetupModel.Update(msg)
+		if m.GameModel != nil {
+			m.GameModel, _ = m.GameModel.Update(msg)
+		}
+		if m.GameOverModel != nil {
+			m.GameOverModel, _ = m.GameOverModel.Update(msg)
+		}
+		if m.LeaderboardModel != nil {
+			m.LeaderboardModel, _ = m.LeaderboardModel.Update(msg)
+		}
+		if m.SettingsModel != nil {
+			m.SettingsModel, _ = m.SettingsModel.Update(msg)
+		}
+		if m.TutorialModel != nil {
+			m.TutorialModel, _ = m.TutorialModel.Update(msg)
+		}
+		return m, nil
+
 	case IntroSubmitMsg:
 		switch msg {
-		case 0:
+		case IntroRegister:
 			m.CurrentScreen = SetupScreen
 			return m, m.SetupModel.Init()
-		case 1:
+		case IntroLeaderboard:
 			m.CurrentScreen = LeaderboardScreen
-			m.LeaderboardModel = NewLeaderboardModel(game.NewHighScoreService(), m.ScreenWidth, m.ScreenHeight)
+			m.LeaderboardModel = NewLeaderboardModel(game.NewHighScoreService(), m.PlayerName, m.Theme, m.ScreenWidth, m.ScreenHeight)
 			return m, m.LeaderboardModel.Init()
+		case IntroTutorial:
+			m.CurrentScreen = TutorialScreen
+			m.TutorialModel = NewTutorialModel(game.NewSandbox(), m.Keys, m.Theme, m.ScreenWidth, m.ScreenHeight)
+			return m, m.TutorialModel.Init()
 		}
 
+	case CloseTutorialMsg:
+		m.CurrentScreen = IntroScreen
+		m.TutorialModel = nil
+		return m, m.IntroModel.Init()
+
 	case ShowGameOverMsg:
 		m.CurrentScreen = GameOverScreen
 		m.GameOverModel = NewGameOverModel(
 			m.GameManager,
 			msg.FinalEstate,
 			msg.FinalKills,
+			msg.FinalTilesStolen,
 			msg.LeaderboardData,
 			msg.EstateInfo,
+			msg.Summary,
+			m.Theme,
 			m.ScreenWidth,
 			m.ScreenHeight,
 		)
@@ -122,7 +216,7 @@ func (m ControllerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case ShowLeaderboardFromGameOverMsg:
 		m.CurrentScreen = LeaderboardScreen
-		m.LeaderboardModel = NewLeaderboardModel(game.NewHighScoreService(), m.ScreenWidth, m.ScreenHeight)
+		m.LeaderboardModel = NewLeaderboardModel(game.NewHighScoreService(), m.PlayerName, m.Theme, m.ScreenWidth, m.ScreenHeight)
 		return m, m.LeaderboardModel.Init()
 
 	case ReturnFromLeaderboardMsg:
@@ -143,6 +237,15 @@ func (m ControllerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.CurrentUserSession = nil
 		return m, m.IntroModel.Init()
 
+	case OpenKeymapSettingsMsg:
+		m.CurrentScreen = KeymapSettingsScreen
+		m.SettingsModel = NewKeymapSettingsModel(m.Keys, m.Theme, m.ScreenWidth, m.ScreenHeight)
+		return m, m.SettingsModel.Init()
+
+	case CloseKeymapSettingsMsg:
+		m.CurrentScreen = SetupScreen
+		return m, nil
+
 	case SetupSubmitMsg:
 		m.CurrentScreen = GameScreen
 		color, conversionErr := strconv.Atoi(msg.Color)
@@ -150,8 +253,14 @@ func (m ControllerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 
+		profile := game.Profile{PlayerName: msg.Name, ColorblindMode: msg.ColorblindMode.String(), Keymap: msg.Keymap}
+		if err := m.Profiles.SaveProfile(profile); err != nil {
+			log.Warn("Could not save profile", "error", err)
+		}
+
+		m.PlayerName = msg.Name
 		m.GameManager.CreateNewPlayer(msg.Name, color, m.CurrentUserSession)
-		m.GameModel = NewGameModel(m.GameManager, m.CurrentUserSession, m.ScreenWidth, m.ScreenHeight)
+		m.GameModel = NewGameModel(m.GameManager, m.CurrentUserSession, m.Theme, m.Keys, m.ScreenWidth, m.ScreenHeight)
 		return m, m.GameModel.Init()
 
 	case QuitGameMsg:
@@ -180,6 +289,16 @@ func (m ControllerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.LeaderboardModel, cmd = m.LeaderboardModel.Update(msg)
 				cmds = append(cmds, cmd)
 			}
+		case KeymapSettingsScreen:
+			if m.SettingsModel != nil {
+				m.SettingsModel, cmd = m.SettingsModel.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+		case TutorialScreen:
+			if m.TutorialModel != nil {
+				m.TutorialModel, cmd = m.TutorialModel.Update(msg)
+				cmds = append(cmds, cmd)
+			}
 		}
 	}
 
@@ -194,6 +313,7 @@ func (m *ControllerModel) logOutUser() {
 				m.GameManager.SessionsToPlayers.Delete(m.CurrentUserSession)
 			}
 		}
+		m.GameManager.Chat.Forget(m.CurrentUserSession)
 		m.CurrentUserSession = nil
 	}
 }